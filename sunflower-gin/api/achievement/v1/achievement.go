@@ -0,0 +1,30 @@
+package v1
+
+// AchievementListResp 成就列表响应结构体
+type AchievementListResp struct {
+	Earned []*AchievementInfo `json:"earned"` // 已获得的成就
+	Locked []*AchievementInfo `json:"locked"` // 还没有获得的成就和进度
+}
+
+// NotificationListResp 获得成就通知响应结构体
+type NotificationListResp struct {
+	List []*AchievementInfo `json:"list"`
+}
+
+// AckNotificationsReq 标记成就通知已查看请求结构体
+type AckNotificationsReq struct {
+	Codes []string `json:"codes" binding:"required,min=1,max=50,dive,max=32"` // 成就编码
+}
+
+// AckNotificationsResp 标记成就通知已查看响应结构体
+type AckNotificationsResp struct{}
+
+// AchievementInfo 成就信息
+type AchievementInfo struct {
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	Description  string `json:"description"` // 获得条件
+	Target       int64  `json:"target"`
+	Progress     int64  `json:"progress"`
+	UnlockedTime string `json:"unlockedTime,omitempty"` // 获得时间，没有获得时为空
+}
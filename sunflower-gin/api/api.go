@@ -3,6 +3,8 @@ package api
 import (
 	"net/http"
 
+	"sunflower-gin/pkg/i18n"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -11,31 +13,64 @@ import (
 	"code": 10000, // 业务错误码
 	"message": xx,     // 提示信息
 	"data": {},    // 数据
+	"requestId": "xx", // 请求ID
 }
 
 */
 
+const (
+	CtxKeyRequestID = "requestId" // 请求ID上下文 key
+)
+
 type ResponseData[T any] struct {
-	Code    ResCode `json:"code"`
-	Message string  `json:"message"`
-	Data    T       `json:"data"`
+	Code      ResCode `json:"code"`
+	Message   string  `json:"message"`
+	Data      T       `json:"data"`
+	RequestID string  `json:"requestId,omitempty"` // 请求ID，便于排查问题时关联日志
 }
 
 // ResponseError 返回错误信息
 func ResponseError(c *gin.Context, code ResCode) {
 	c.JSON(http.StatusOK, &ResponseData[any]{
-		Code:    code,
-		Message: code.Msg(),
-		Data:    nil,
+		Code:      code,
+		Message:   code.MsgIn(i18n.FromContext(c)),
+		Data:      nil,
+		RequestID: c.GetString(CtxKeyRequestID),
 	})
 }
 
 // ResponseErrorWithMsg 返回自定义错误信息
 func ResponseErrorWithMsg(c *gin.Context, code ResCode, msg string) {
 	c.JSON(http.StatusOK, &ResponseData[any]{
-		Code:    code,
-		Message: msg,
-		Data:    nil,
+		Code:      code,
+		Message:   msg,
+		Data:      nil,
+		RequestID: c.GetString(CtxKeyRequestID),
+	})
+}
+
+// ResponseErrorWithErr 返回业务错误信息，可翻译的错误会按请求语言返回
+func ResponseErrorWithErr(c *gin.Context, code ResCode, err error) {
+	ResponseErrorWithMsg(c, code, i18n.ErrorMsg(i18n.FromContext(c), err))
+}
+
+// ResponseInvalidParam 返回参数校验错误，校验失败的原因会按请求语言返回
+func ResponseInvalidParam(c *gin.Context, err error) {
+	msg, ok := i18n.ValidationMsg(i18n.FromContext(c), err)
+	if !ok {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	ResponseErrorWithMsg(c, CodeInvalidParam, msg)
+}
+
+// ResponseErrorWithStatus 返回指定HTTP状态码的错误信息并终止后续处理
+func ResponseErrorWithStatus(c *gin.Context, status int, code ResCode) {
+	c.AbortWithStatusJSON(status, &ResponseData[any]{
+		Code:      code,
+		Message:   code.MsgIn(i18n.FromContext(c)),
+		Data:      nil,
+		RequestID: c.GetString(CtxKeyRequestID),
 	})
 }
 
@@ -47,8 +82,9 @@ func ResponseErrorWithHTTPStatus(c *gin.Context, status int) {
 // ResponseSuccess 返回成功信息
 func ResponseSuccess[T any](c *gin.Context, data T) {
 	c.JSON(http.StatusOK, &ResponseData[T]{
-		Code:    CodeSuccess,
-		Message: CodeSuccess.Msg(),
-		Data:    data,
+		Code:      CodeSuccess,
+		Message:   CodeSuccess.MsgIn(i18n.FromContext(c)),
+		Data:      data,
+		RequestID: c.GetString(CtxKeyRequestID),
 	})
 }
@@ -0,0 +1,74 @@
+package v1
+
+// CampaignReq 管理后台创建和修改活动请求结构体
+type CampaignReq struct {
+	Name        string        `json:"name" binding:"required,max=64"`
+	Type        int32         `json:"type" binding:"required,oneof=1 2"`                         // 1:签到积分倍率 2:签到天数目标，创建后不能修改
+	Enabled     bool          `json:"enabled"`                                                   // 是否启用
+	StartTime   string        `json:"startTime" binding:"required,datetime=2006-01-02 15:04:05"` // 开始时间（包含）
+	EndTime     string        `json:"endTime" binding:"required,datetime=2006-01-02 15:04:05"`   // 结束时间（不包含）
+	Multiplier  int32         `json:"multiplier" binding:"omitempty,gt=100,lte=1000"`            // 倍率活动必填，百分比，200 表示双倍
+	GoalDays    int32         `json:"goalDays" binding:"omitempty,gt=0,lte=366"`                 // 目标活动必填，活动期间需要签到的天数
+	BonusPoints int64         `json:"bonusPoints" binding:"omitempty,gt=0"`                      // 目标活动必填，达成目标的奖励积分
+	Budget      int64         `json:"budget" binding:"gte=0"`                                    // 活动预算，0 表示不限制，修改时只能增加
+	Rules       CampaignRules `json:"rules"`
+}
+
+// CampaignRules 活动的参与条件，不传表示不限制
+type CampaignRules struct {
+	Weekdays          []int `json:"weekdays" binding:"omitempty,dive,min=1,max=7"` // 只在每周的这几天生效，1-7 表示周一到周日，例如周末双倍积分传 [6,7]
+	NewUserDays       int   `json:"newUserDays" binding:"gte=0"`                   // 只有注册不超过这么多天的用户可以参与
+	MinLifetimePoints int64 `json:"minLifetimePoints" binding:"gte=0"`             // 累计获得的积分达到这个数量才能参与
+}
+
+// DeleteCampaignResp 管理后台删除活动响应结构体
+type DeleteCampaignResp struct{}
+
+// CampaignListReq 管理后台活动列表请求结构体
+type CampaignListReq struct {
+	Status int32 `form:"status" binding:"omitempty,oneof=1 2"` // 1:启用 2:停用，不传时都查
+	Offset int   `form:"offset"`
+	Limit  int   `form:"limit"`
+}
+
+// CampaignListResp 管理后台活动列表响应结构体
+type CampaignListResp struct {
+	Total int64           `json:"total"`
+	List  []*CampaignInfo `json:"list"`
+}
+
+// CampaignInfo 管理后台的活动信息
+type CampaignInfo struct {
+	ID              int64         `json:"id"`
+	Name            string        `json:"name"`
+	Type            int32         `json:"type"`
+	Enabled         bool          `json:"enabled"`
+	StartTime       string        `json:"startTime"`
+	EndTime         string        `json:"endTime"`
+	Multiplier      int32         `json:"multiplier,omitempty"`
+	GoalDays        int32         `json:"goalDays,omitempty"`
+	BonusPoints     int64         `json:"bonusPoints,omitempty"`
+	Budget          int64         `json:"budget"`
+	RemainingBudget int64         `json:"remainingBudget"` // 活动奖池的余额，没有设置预算时为 0
+	Rules           CampaignRules `json:"rules"`
+	CreatedTime     string        `json:"createdTime"`
+}
+
+// UserCampaignListResp 用户可以参与的活动列表响应结构体
+type UserCampaignListResp struct {
+	List []*UserCampaignInfo `json:"list"`
+}
+
+// UserCampaignInfo 用户可以参与的活动，目标活动带上签到进度
+type UserCampaignInfo struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Type        int32  `json:"type"` // 1:签到积分倍率 2:签到天数目标
+	StartTime   string `json:"startTime"`
+	EndTime     string `json:"endTime"`
+	Multiplier  int32  `json:"multiplier,omitempty"`
+	GoalDays    int32  `json:"goalDays,omitempty"`
+	BonusPoints int64  `json:"bonusPoints,omitempty"`
+	CheckinDays int32  `json:"checkinDays"` // 目标活动中已经签到的天数
+	Completed   bool   `json:"completed"`   // 目标活动是否已经达成
+}
@@ -19,6 +19,7 @@ type DetailInfo struct {
 	RetroCheckedInDays []int `json:"retroCheckedInDays"` // 补签的日期序号
 	IsCheckedInToday   bool  `json:"isCheckedInToday"`   // 今天是否签到
 	RemainRetroTimes   int   `json:"remainRetroTimes"`   // 剩余补签次数
+	RetroCards         int64 `json:"retroCards"`         // 剩余补签卡张数，补签时优先使用
 	ConsecutiveDays    int   `json:"consecutiveDays"`    // 连续签到天数
 }
 
@@ -29,3 +30,11 @@ type RetroReq struct {
 
 // RetroResp 补签响应参数
 type RetroResp struct{}
+
+// StatusResp 内部接口查询签到状态响应参数
+type StatusResp struct {
+	UserID           int64 `json:"userId"`
+	IsCheckedInToday bool  `json:"isCheckedInToday"` // 今天是否签到
+	ConsecutiveDays  int   `json:"consecutiveDays"`  // 截止到今天的连续签到天数，今天还没有签到时截止到昨天
+	MonthDays        int   `json:"monthDays"`        // 本月签到的天数，包含补签
+}
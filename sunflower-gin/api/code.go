@@ -1,5 +1,7 @@
 package api
 
+import "sunflower-gin/pkg/i18n"
+
 // ResCode 定义返回码类型
 type ResCode int64
 
@@ -13,26 +15,43 @@ const (
 
 	CodeNeedLogin    ResCode = 4100
 	CodeInvalidToken ResCode = 4200
+	CodeForbidden    ResCode = 4300
+
+	CodeConflict ResCode = 4090
+
+	CodeTooManyRequests ResCode = 4290
 
 	CodeServerBusy ResCode = 5000
 )
 
+// codeMsgMap 返回码对应的多语言文案 key，文案定义在 pkg/i18n/locales 中
 var codeMsgMap = map[ResCode]string{
-	CodeSuccess:         "success",
-	CodeInvalidParam:    "请求参数错误",
-	CodeUserExist:       "用户名已存在",
-	CodeUserNotExist:    "用户名不存在",
-	CodeInvalidPassword: "用户名或密码错误",
-	CodeServerBusy:      "服务繁忙",
-
-	CodeNeedLogin:    "需要登录",
-	CodeInvalidToken: "无效的token",
+	CodeSuccess:         "code.success",
+	CodeInvalidParam:    "code.invalid_param",
+	CodeUserExist:       "code.user_exist",
+	CodeUserNotExist:    "code.user_not_exist",
+	CodeInvalidPassword: "code.invalid_password",
+	CodeServerBusy:      "code.server_busy",
+
+	CodeNeedLogin:    "code.need_login",
+	CodeInvalidToken: "code.invalid_token",
+	CodeForbidden:    "code.forbidden",
+
+	CodeConflict: "code.conflict",
+
+	CodeTooManyRequests: "code.too_many_requests",
 }
 
+// Msg 返回码在默认语言下的提示信息
 func (c ResCode) Msg() string {
-	msg, ok := codeMsgMap[c]
+	return c.MsgIn(i18n.DefaultLang)
+}
+
+// MsgIn 返回码在指定语言下的提示信息
+func (c ResCode) MsgIn(lang string) string {
+	key, ok := codeMsgMap[c]
 	if !ok {
-		msg = codeMsgMap[CodeServerBusy]
+		key = codeMsgMap[CodeServerBusy]
 	}
-	return msg
+	return i18n.T(lang, key)
 }
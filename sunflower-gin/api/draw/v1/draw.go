@@ -0,0 +1,55 @@
+package v1
+
+// DrawResp 抽奖响应结构体
+type DrawResp struct {
+	DrawInfo
+}
+
+// PoolResp 奖品池响应结构体
+type PoolResp struct {
+	Enabled    bool         `json:"enabled"`
+	CanDraw    bool         `json:"canDraw"`         // 今天已经签到、还没有抽奖并且还有奖品
+	Today      *DrawInfo    `json:"today,omitempty"` // 今天的抽奖结果
+	RetroCards int64        `json:"retroCards"`      // 剩余的补签卡张数
+	Prizes     []*PrizeInfo `json:"prizes"`
+}
+
+// PrizeInfo 奖品信息
+type PrizeInfo struct {
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`                // points:积分 retro_card:补签卡 coupon:优惠券 none:谢谢参与
+	Amount      int64   `json:"amount"`              // 积分数量、补签卡张数或优惠券面额
+	Probability float64 `json:"probability"`         // 抽中的概率，库存不足时为 0
+	Stock       int64   `json:"stock"`               // 总库存，0 表示不限制
+	Remaining   int64   `json:"remaining,omitempty"` // 剩余库存，只有限制库存的奖品有值
+}
+
+// DrawListReq 抽奖记录请求结构体
+type DrawListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+// DrawListResp 抽奖记录响应结构体
+type DrawListResp struct {
+	Total int64       `json:"total"`
+	List  []*DrawInfo `json:"list"`
+}
+
+// DrawInfo 抽奖结果，用 serverSeed 可以复现抽中的位置
+type DrawInfo struct {
+	ID               int64  `json:"id"`
+	DrawDate         string `json:"drawDate"`
+	PrizeCode        string `json:"prizeCode"`
+	PrizeName        string `json:"prizeName"`
+	PrizeType        string `json:"prizeType"`
+	Amount           int64  `json:"amount"`
+	CouponCode       string `json:"couponCode,omitempty"`
+	CouponExpireTime string `json:"couponExpireTime,omitempty"`
+	ServerSeed       string `json:"serverSeed"` // 服务端种子，hex 编码
+	Nonce            int32  `json:"nonce"`
+	Roll             int64  `json:"roll"`        // 抽中的位置
+	TotalWeight      int64  `json:"totalWeight"` // 抽奖时有库存的奖品的权重之和
+	CreatedTime      string `json:"createdTime"`
+}
@@ -0,0 +1,15 @@
+package v1
+
+// HealthResp 健康检查响应结构体
+type HealthResp struct {
+	Status  string                 `json:"status"`           // 整体状态 up/down
+	Version string                 `json:"version"`          // 服务版本
+	Checks  map[string]CheckResult `json:"checks,omitempty"` // 各依赖的检查结果
+}
+
+// CheckResult 单个依赖的检查结果
+type CheckResult struct {
+	Status  string `json:"status"`          // up/down
+	Latency string `json:"latency"`         // 检查耗时
+	Error   string `json:"error,omitempty"` // 失败原因
+}
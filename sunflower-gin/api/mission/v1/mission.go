@@ -0,0 +1,40 @@
+package v1
+
+// MissionListResp 任务列表响应结构体
+type MissionListResp struct {
+	List []*MissionInfo `json:"list"`
+}
+
+// MissionInfo 任务信息和当前周期的进度
+type MissionInfo struct {
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	Period        string `json:"period"` // daily:每日任务 weekly:每周任务 once:一次性任务
+	Target        int64  `json:"target"`
+	Points        int64  `json:"points"` // 完成后可以领取的积分
+	Progress      int64  `json:"progress"`
+	Completed     bool   `json:"completed"`
+	Claimed       bool   `json:"claimed"`
+	CompletedTime string `json:"completedTime,omitempty"` // 完成时间，没有完成时为空
+	ResetTime     string `json:"resetTime,omitempty"`     // 下一次重置的时间，一次性任务为空
+}
+
+// ClaimResp 领取任务奖励响应结构体
+type ClaimResp struct {
+	Code   string `json:"code"`
+	Points int64  `json:"points"` // 领取的积分
+}
+
+// ReportEventReq 内部接口上报任务事件请求结构体
+type ReportEventReq struct {
+	EventID string `json:"eventId" binding:"required,max=64"`        // 事件ID，由上报方生成，重复上报同一个事件只处理一次
+	UserID  int64  `json:"userId" binding:"required,gt=0"`           // 产生事件的用户
+	Event   string `json:"event" binding:"required,max=32"`          // 事件类型，对应任务定义中的 event
+	Count   int64  `json:"count" binding:"omitempty,gt=0,lte=10000"` // 事件次数，不传时为 1
+}
+
+// ReportEventResp 内部接口上报任务事件响应结构体
+type ReportEventResp struct {
+	Duplicate bool     `json:"duplicate"` // 事件已经处理过，这次没有累加进度
+	Completed []string `json:"completed"` // 这次事件完成的任务编码
+}
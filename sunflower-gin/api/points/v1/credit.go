@@ -0,0 +1,16 @@
+package v1
+
+// CreditReq 内部接口发放积分请求结构体
+type CreditReq struct {
+	RequestID string `json:"requestId" binding:"required,max=64"` // 请求ID，由调用方生成，重复提交同一个请求ID只发放一次
+	UserID    int64  `json:"userId" binding:"required,gt=0"`
+	Points    int64  `json:"points" binding:"required,gt=0"`
+	Reason    string `json:"reason" binding:"required,max=128"` // 发放原因，显示在用户的积分记录中
+}
+
+// CreditResp 内部接口发放积分响应结构体
+type CreditResp struct {
+	CreditID  int64 `json:"creditId"`
+	Points    int64 `json:"points"`    // 发放的积分，重复提交时为第一次发放的积分
+	Duplicate bool  `json:"duplicate"` // 请求ID已经处理过，这次没有发放
+}
@@ -6,15 +6,22 @@ type SummaryResp struct {
 
 // RecordsReq 积分记录请求结构体
 type RecordsReq struct {
-	Offset int `form:"offset"`
-	Limit  int `form:"limit"`
+	Cursor    string  `form:"cursor"` // 上一页返回的 nextCursor，第一页不传
+	Limit     int     `form:"limit"`
+	Offset    int     `form:"offset"`                                            // Deprecated: 旧版本的偏移量分页，传了 cursor 时忽略
+	Type      []int32 `form:"type"`                                              // 交易类型，可以传多个，如 type=1&type=2
+	Sign      string  `form:"sign" binding:"omitempty,oneof=earn spend"`         // earn 只查收入，spend 只查支出
+	StartDate string  `form:"startDate" binding:"omitempty,datetime=2006-01-02"` // 开始日期，包含当天
+	EndDate   string  `form:"endDate" binding:"omitempty,datetime=2006-01-02"`   // 结束日期，包含当天
+	WithTotal bool    `form:"withTotal"`                                         // 是否返回总数，总数需要额外查询，按需开启
 }
 
 // RecordsResp 积分记录响应结构体
 type RecordsResp struct {
-	Total   int64         `json:"total"`
-	HasMore bool          `json:"hasMore"` // 是否还有更多数据
-	List    []*RecordInfo `json:"list"`
+	Total      *int64        `json:"total,omitempty"`      // 请求时 withTotal=true 才返回
+	HasMore    bool          `json:"hasMore"`              // 是否还有更多数据
+	NextCursor string        `json:"nextCursor,omitempty"` // 下一页的游标，没有更多数据时为空
+	List       []*RecordInfo `json:"list"`
 }
 
 type RecordInfo struct {
@@ -23,3 +30,40 @@ type RecordInfo struct {
 	Description     string `json:"description"`
 	TransactionTime string `json:"transactionTime"`
 }
+
+// StatsReq 积分统计请求结构体
+type StatsReq struct {
+	Granularity string `form:"granularity" binding:"omitempty,oneof=day week month"` // 时间序列的统计粒度，默认 day
+	StartDate   string `form:"startDate" binding:"omitempty,datetime=2006-01-02"`    // 开始日期，包含当天
+	EndDate     string `form:"endDate" binding:"omitempty,datetime=2006-01-02"`      // 结束日期，包含当天
+}
+
+// StatsResp 积分统计响应结构体
+type StatsResp struct {
+	Balance        int64          `json:"balance"`        // 当前余额
+	LifetimeEarned int64          `json:"lifetimeEarned"` // 累计获得
+	LifetimeSpent  int64          `json:"lifetimeSpent"`  // 累计消耗
+	ByType         []*TypeStats   `json:"byType"`         // 按交易类型汇总
+	Granularity    string         `json:"granularity"`
+	Series         []*SeriesPoint `json:"series"` // 按时间周期汇总，没有记录的周期为 0
+}
+
+type TypeStats struct {
+	TransactionType int32 `json:"transactionType"`
+	Earned          int64 `json:"earned"`
+	Spent           int64 `json:"spent"`
+	Count           int64 `json:"count"`
+}
+
+type SeriesPoint struct {
+	Period string `json:"period"` // 日和周为 2006-01-02（周一），月为 2006-01
+	Earned int64  `json:"earned"`
+	Spent  int64  `json:"spent"`
+}
+
+// ExportReq 导出积分记录请求结构体
+type ExportReq struct {
+	Format    string `form:"format" binding:"omitempty,oneof=csv xlsx"`         // 导出格式，默认 csv
+	StartDate string `form:"startDate" binding:"omitempty,datetime=2006-01-02"` // 开始日期，包含当天
+	EndDate   string `form:"endDate" binding:"omitempty,datetime=2006-01-02"`   // 结束日期，包含当天
+}
@@ -0,0 +1,41 @@
+package v1
+
+// TransferReq 发起转赠请求结构体
+type TransferReq struct {
+	ToUsername string `json:"toUsername" binding:"required"`     // 接收人用户名
+	Amount     int64  `json:"amount" binding:"required,gt=0"`    // 转赠积分
+	Remark     string `json:"remark" binding:"omitempty,max=64"` // 留言
+}
+
+// ConfirmTransferReq 确认大额转赠请求结构体
+type ConfirmTransferReq struct {
+	Password string `json:"password" binding:"required"` // 当前用户的登录密码
+}
+
+// TransferListReq 转赠记录请求结构体
+type TransferListReq struct {
+	Direction string `form:"direction" binding:"omitempty,oneof=in out"` // in 只查转入，out 只查转出，不传时都查
+	Cursor    string `form:"cursor"`                                     // 上一页返回的 nextCursor，第一页不传
+	Limit     int    `form:"limit"`
+}
+
+// TransferListResp 转赠记录响应结构体
+type TransferListResp struct {
+	HasMore    bool            `json:"hasMore"`              // 是否还有更多数据
+	NextCursor string          `json:"nextCursor,omitempty"` // 下一页的游标，没有更多数据时为空
+	List       []*TransferInfo `json:"list"`
+}
+
+// TransferInfo 转赠信息，发起转赠和确认转赠也返回这个结构
+type TransferInfo struct {
+	TransferNo   int64  `json:"transferNo,string"` // 转赠单号，超过 JS 的安全整数范围，按字符串返回
+	Direction    string `json:"direction"`         // in 转入，out 转出
+	Counterparty string `json:"counterparty"`      // 对方的用户名
+	Amount       int64  `json:"amount"`
+	Status       int32  `json:"status"`      // 1:待确认 2:已完成 3:已过期
+	NeedConfirm  bool   `json:"needConfirm"` // 是否需要调用确认接口
+	Remark       string `json:"remark"`
+	CreatedTime  string `json:"createdTime"`
+	CompleteTime string `json:"completeTime,omitempty"` // 完成时间，未完成时为空
+	ExpireTime   string `json:"expireTime,omitempty"`   // 待确认的转赠的过期时间
+}
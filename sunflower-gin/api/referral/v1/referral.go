@@ -0,0 +1,36 @@
+package v1
+
+// DashboardResp 邀请信息响应结构体
+type DashboardResp struct {
+	Code             string `json:"code"`             // 当前用户的邀请码
+	RequiredCheckins int32  `json:"requiredCheckins"` // 被邀请人完成这么多次每日签到后发放奖励
+	InviterPoints    int64  `json:"inviterPoints"`    // 每邀请一人可以获得的积分
+	InviteePoints    int64  `json:"inviteePoints"`    // 被邀请人可以获得的积分
+	Invited          int64  `json:"invited"`          // 邀请的总人数
+	Pending          int64  `json:"pending"`          // 等待签到的人数
+	Rewarded         int64  `json:"rewarded"`         // 已经发放奖励的人数
+	Rejected         int64  `json:"rejected"`         // 触发防刷规则不发放奖励的人数
+	EarnedPoints     int64  `json:"earnedPoints"`     // 已经获得的邀请奖励积分
+}
+
+// InviteeListReq 邀请记录请求结构体
+type InviteeListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+// InviteeListResp 邀请记录响应结构体
+type InviteeListResp struct {
+	Total int64          `json:"total"`
+	List  []*InviteeInfo `json:"list"`
+}
+
+// InviteeInfo 一个被邀请人
+type InviteeInfo struct {
+	Username       string `json:"username"`
+	Status         int32  `json:"status"`                 // 1:等待签到 2:已发放奖励 3:不发放奖励
+	Checkins       int32  `json:"checkins"`               // 注册后完成的每日签到次数
+	EarnedPoints   int64  `json:"earnedPoints"`           // 邀请人获得的奖励积分
+	RegisteredTime string `json:"registeredTime"`         // 注册时间
+	RewardedTime   string `json:"rewardedTime,omitempty"` // 发放奖励的时间
+}
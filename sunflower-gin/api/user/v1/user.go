@@ -6,6 +6,7 @@ type CreateReq struct {
 	Email           string `json:"email" binding:"required,email"`
 	Password        string `json:"password" binding:"required"`
 	ConfirmPassword string `json:"confirmPassword" binding:"eqfield=Password"`
+	InviteCode      string `json:"inviteCode" binding:"omitempty,max=16"` // 邀请码，选填
 }
 
 // CreateRes 创建响应结构体
@@ -14,8 +15,55 @@ type CreateRes struct {
 	Username string `json:"username"`
 }
 
+// UpdateMeReq 修改当前用户信息，字段为空时不修改
+type UpdateMeReq struct {
+	Email  string `json:"email" binding:"omitempty,email"`
+	Avatar string `json:"avatar" binding:"omitempty,url,max=255"`
+}
+
 type MeRes struct {
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Avatar   string `json:"avatar"`
+	Username string    `json:"username"`
+	Email    string    `json:"email"`
+	Avatar   string    `json:"avatar"`
+	Tier     *TierInfo `json:"tier"` // 用户等级，查询失败时为 null
+}
+
+// TierInfo 用户当前等级和升级进度
+type TierInfo struct {
+	Level          int32            `json:"level"` // 0 表示没有等级
+	Name           string           `json:"name"`
+	LifetimePoints int64            `json:"lifetimePoints"` // 累计获得的积分
+	BestStreak     int32            `json:"bestStreak"`     // 最长连续签到天数
+	Next           *TierRequirement `json:"next"`           // 下一个等级，已经是最高等级时为 null
+}
+
+// TierRequirement 达到等级的条件，满足任一条件即可，为 0 的条件不计算
+type TierRequirement struct {
+	Level             int32  `json:"level"`
+	Name              string `json:"name"`
+	MinLifetimePoints int64  `json:"minLifetimePoints"`
+	MinStreakDays     int    `json:"minStreakDays"`
+}
+
+// TierHistoryReq 等级变化记录请求结构体
+type TierHistoryReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+// TierHistoryResp 等级变化记录响应结构体
+type TierHistoryResp struct {
+	Total int64              `json:"total"`
+	List  []*TierHistoryInfo `json:"list"`
+}
+
+// TierHistoryInfo 一次等级变化
+type TierHistoryInfo struct {
+	FromLevel      int32  `json:"fromLevel"`
+	FromName       string `json:"fromName"`
+	ToLevel        int32  `json:"toLevel"`
+	ToName         string `json:"toName"`
+	LifetimePoints int64  `json:"lifetimePoints"` // 变化时累计获得的积分
+	BestStreak     int32  `json:"bestStreak"`     // 变化时最长连续签到天数
+	CreatedTime    string `json:"createdTime"`
 }
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"regexp"
+
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 签到 key 迁移
+// user:checkins:daily:123:2025    -> user:checkins:daily:{123}:2025
+// user:checkins:retro:123:2025:01 -> user:checkins:retro:{123}:2025:01
+// 新旧 key 在集群模式下可能不在同一个 slot，所以不用 RENAME，而是把旧 key 上为 1 的位逐个 SETBIT 到新 key 上，
+// 这样即使新 key 已经有了数据（如迁移前已经用新版本签到过）也只会合并不会覆盖，重复执行也没有影响
+
+var oldCheckinKeyRe = regexp.MustCompile(`^user:checkins:(daily|retro):(\d+):(.+)$`)
+
+func migrateCheckinKeys(ctx context.Context, cfg *conf.Config, args []string) error {
+	fs := flag.NewFlagSet("migrate-checkin-keys", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "只打印需要迁移的 key，不做修改")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	dao.MustInitRedis(&cfg.Redis)
+	defer dao.RedisClient.Close()
+
+	var migrated int
+	migrate := func(ctx context.Context, client redis.UniversalClient) error {
+		iter := client.Scan(ctx, 0, "user:checkins:*", 1000).Iterator()
+		for iter.Next(ctx) {
+			oldKey := iter.Val()
+			m := oldCheckinKeyRe.FindStringSubmatch(oldKey)
+			if m == nil {
+				continue // 已经是新格式
+			}
+			newKey := fmt.Sprintf("user:checkins:%s:{%s}:%s", m[1], m[2], m[3])
+			fmt.Printf("%s -> %s\n", oldKey, newKey)
+			if !*dryRun {
+				if err := moveBitmap(ctx, oldKey, newKey); err != nil {
+					return fmt.Errorf("migrate %s failed, err:%w", oldKey, err)
+				}
+			}
+			migrated++
+		}
+		return iter.Err()
+	}
+	// 集群模式需要在每个主节点上分别 SCAN
+	if cc, ok := dao.RedisClient.(*redis.ClusterClient); ok {
+		err := cc.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+			return migrate(ctx, client)
+		})
+		if err != nil {
+			return err
+		}
+	} else if err := migrate(ctx, dao.RedisClient); err != nil {
+		return err
+	}
+	fmt.Printf("migrated %d keys, dry run: %v\n", migrated, *dryRun)
+	return nil
+}
+
+// moveBitmap 把旧 key 的 bitmap 合并到新 key 上，保留过期时间，然后删除旧 key
+func moveBitmap(ctx context.Context, oldKey, newKey string) error {
+	rdb := dao.RedisClient
+	val, err := rdb.Get(ctx, oldKey).Bytes()
+	if err != nil {
+		return err
+	}
+	ttl, err := rdb.PTTL(ctx, oldKey).Result()
+	if err != nil {
+		return err
+	}
+	pipe := rdb.Pipeline()
+	for i, b := range val {
+		for j := range 8 {
+			if b&(0x80>>j) != 0 { // Redis bitmap 的 offset 0 是第一个字节的最高位
+				pipe.SetBit(ctx, newKey, int64(i*8+j), 1)
+			}
+		}
+	}
+	if ttl > 0 {
+		pipe.PExpire(ctx, newKey, ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	return rdb.Del(ctx, oldKey).Err()
+}
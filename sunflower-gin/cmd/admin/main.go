@@ -0,0 +1,120 @@
+package main
+
+// 运维命令行工具，用于数据迁移等一次性操作
+// go run ./cmd/admin -conf ./config/config.yaml <command> [command flags]
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/tenant"
+	"sunflower-gin/pkg/logging"
+)
+
+var (
+	confPath = flag.String("conf", "./config/config.yaml", "配置文件路径")
+	env      = flag.String("env", os.Getenv(conf.EnvPrefix+"_ENV"), "运行环境，会额外加载 config.{env}.yaml 覆盖基础配置")
+	tenantID = flag.String("tenant", "", "只处理指定的租户，为空时处理所有租户")
+)
+
+// command 子命令，run 中自行初始化需要用到的 MySQL、Redis 等依赖
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, cfg *conf.Config, args []string) error
+}
+
+var commands = []command{
+	{name: "migrate-checkin-keys", usage: "把旧格式的签到 key 迁移到带 hash tag 的新格式", run: migrateCheckinKeys},
+	{name: "reconcile", usage: "积分对账，检查余额和流水是否一致，-repair 追加校正流水", run: reconcilePoints},
+	{name: "evaluate-tiers", usage: "按当前的等级规则重新计算所有用户的等级", run: evaluateTiers},
+	{name: "create-api-key", usage: "创建内部接口的 API key，-tenant 指定所属租户，默认为 default", run: createAPIKey},
+	{name: "list-api-keys", usage: "列出内部接口的 API key", run: listAPIKeys},
+	{name: "revoke-api-key", usage: "吊销内部接口的 API key", run: revokeAPIKey},
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	name, args := flag.Arg(0), flag.Args()[1:]
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == name {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", name)
+		usage()
+		os.Exit(2)
+	}
+
+	cfg := conf.MustLoad(*confPath, *env)
+	logger, err := logging.NewLogger(&cfg.Log, cfg.Server.Mode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "init logger failed, err:%v\n", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	// Ctrl+C 时停止处理剩余数据
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := cmd.run(ctx, cfg, args); err != nil {
+		fmt.Fprintf(os.Stderr, "%s failed, err:%v\n", cmd.name, err)
+		os.Exit(1)
+	}
+}
+
+// forEachTenant 对 -tenant 指定的租户执行，未指定时依次处理所有租户
+func forEachTenant(ctx context.Context, fn func(ctx context.Context) error) error {
+	ids := tenant.IDs()
+	if *tenantID != "" {
+		if !slices.Contains(ids, *tenantID) {
+			return fmt.Errorf("unknown tenant %q", *tenantID)
+		}
+		ids = []string{*tenantID}
+	}
+	var errs []error
+	for _, id := range ids {
+		if len(ids) > 1 {
+			fmt.Printf("tenant %s:\n", id)
+		}
+		if err := fn(tenant.WithID(ctx, id)); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// withTenant 只操作一个租户的命令，使用 -tenant 指定的租户，未指定时为默认租户
+func withTenant(ctx context.Context) (context.Context, error) {
+	id := *tenantID
+	if id == "" {
+		id = tenant.Default
+	}
+	if !slices.Contains(tenant.IDs(), id) {
+		return nil, fmt.Errorf("unknown tenant %q", id)
+	}
+	return tenant.WithID(ctx, id), nil
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: admin [flags] <command> [command flags]\n\nflags:\n")
+	flag.PrintDefaults()
+	fmt.Fprintf(os.Stderr, "\ncommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-24s %s\n", c.name, c.usage)
+	}
+}
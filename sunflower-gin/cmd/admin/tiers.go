@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/service/tier"
+)
+
+// 按当前的等级规则重新计算所有用户的等级，修改 reward.tiers 后执行，也用于上线后初始化历史用户的等级
+// go run ./cmd/admin evaluate-tiers
+
+func evaluateTiers(ctx context.Context, cfg *conf.Config, args []string) error {
+	dao.MustInitMySQL(&cfg.MySQL)
+	defer dao.Close()
+
+	return forEachTenant(ctx, func(ctx context.Context) error {
+		output, err := tier.EvaluateAll(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("checked %d users, upgraded %d, downgraded %d\n", output.Checked, output.Upgraded, output.Downgraded)
+		return nil
+	})
+}
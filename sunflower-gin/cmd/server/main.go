@@ -2,48 +2,113 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"sunflower-gin/internal/conf"
 	"sunflower-gin/internal/dao"
 	"sunflower-gin/internal/server"
 	"sunflower-gin/internal/task"
+	"sunflower-gin/pkg/i18n"
 	"sunflower-gin/pkg/jwt"
 	"sunflower-gin/pkg/logging"
 	"sunflower-gin/pkg/snowflake"
+	"sunflower-gin/pkg/tracing"
+
+	"go.uber.org/zap"
 )
 
-var confPath = flag.String("conf", "./config/config.yaml", "配置文件路径")
+var (
+	confPath = flag.String("conf", "./config/config.yaml", "配置文件路径")
+	env      = flag.String("env", os.Getenv(conf.EnvPrefix+"_ENV"), "运行环境，会额外加载 config.{env}.yaml 覆盖基础配置")
+)
 
 func main() {
 	// 加载配置
 	flag.Parse()
-	cfg := conf.Load(*confPath)
+	cfg := conf.MustLoad(*confPath, *env)
 
 	// 初始化日志
-	logger, err := logging.NewLogger(cfg)
+	logger, err := logging.NewLogger(&cfg.Log, cfg.Server.Mode)
 	if err != nil {
 		fmt.Printf("init logger failed, err:%v\n", err)
 		return
 	}
 	defer logger.Sync()
 
-	dao.MustInitMySQL(cfg)  // 初始化 MySQL 连接
-	dao.MustInitRedis(cfg)  // 初始化 Redis
-	jwt.MustInit(cfg)       // 初始化 jwt
-	snowflake.MustInit(cfg) // 初始化 snowflake
+	// 配置热更新
+	conf.OnReload(func(cfg *conf.Config) {
+		if err := logging.SetLevel(cfg.Log.Level); err != nil {
+			zap.L().Error("set log level failed", zap.Error(err))
+		}
+	})
+	conf.Watch()
+
+	// 初始化链路追踪，需要在 MySQL、Redis 之前
+	shutdownTracing := tracing.MustInit(&cfg.Tracing, tracing.Service{
+		Name:    cfg.Server.Name,
+		Version: cfg.Server.Version,
+		Env:     cfg.Server.Mode,
+	})
+	dao.MustInitMySQL(&cfg.MySQL)      // 初始化 MySQL 连接
+	dao.MustInitRedis(&cfg.Redis)      // 初始化 Redis
+	jwt.MustInit(&cfg.JWT)             // 初始化 jwt
+	snowflake.MustInit(&cfg.Snowflake) // 初始化 snowflake
+	i18n.MustInitValidator()           // 初始化参数校验错误的翻译
 
 	// 初始化路由
 	r := server.SetupRoutes(cfg)
 
-	// 定时任务
-	c := task.MustInit(context.Background())
-	defer c.Stop()
+	// 定时任务，服务退出时取消 jobCtx 通知仍在执行的任务尽快结束
+	jobCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+	c := task.MustInit(jobCtx, &cfg.Task)
 
 	// 启动服务
-	err = r.Run(fmt.Sprintf(":%d", cfg.GetInt("server.port")))
-	if err != nil {
-		panic(err)
+	srv := &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler: r,
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zap.L().Fatal("listen failed", zap.Error(err))
+		}
+	}()
+
+	// 等待中断信号，优雅地关闭服务
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	stop() // 再次收到信号时直接退出
+	zap.L().Info("shutdown server ...")
+
+	// 1. 停止接收新请求，等待处理中的请求完成
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		zap.L().Error("server shutdown failed", zap.Error(err))
+	}
+	// 2. 停止定时任务调度，等待正在执行的任务完成
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), cfg.Task.StopTimeout)
+	defer cancelStop()
+	if err := task.Stop(stopCtx, c); err != nil {
+		zap.L().Error("stop cron failed", zap.Error(err))
+	}
+	cancelJobs()
+	// 3. 关闭 MySQL 和 Redis 连接
+	if err := dao.Close(); err != nil {
+		zap.L().Error("close dao failed", zap.Error(err))
+	}
+	// 4. 上报剩余的链路追踪数据
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancelFlush()
+	if err := shutdownTracing(flushCtx); err != nil {
+		zap.L().Error("shutdown tracing failed", zap.Error(err))
 	}
+	zap.L().Info("server exited")
 }
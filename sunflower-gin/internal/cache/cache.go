@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/metrics"
+	"sunflower-gin/internal/tenant"
+	"sunflower-gin/pkg/logging"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// Redis 缓存，采用 cache-aside 模式
+// 1. 读：先读缓存，未命中时回源查库并写入缓存，同一个 key 的并发回源通过 singleflight 合并成一次
+// 2. 写：先更新数据库，成功后删除缓存，下次读取时重新加载
+// 3. Redis 异常时直接回源，缓存只影响性能不影响正确性
+// 回源和删除之间存在并发时可能把旧数据写回缓存，依靠过期时间兜底
+
+const (
+	userProfileKeyFormat   = "cache:user:profile:%d"   // cache:user:profile:123213131
+	pointsSummaryKeyFormat = "cache:points:summary:%d" // cache:points:summary:123213131
+	activeCampaignsKey     = "cache:campaigns:active"
+)
+
+// 缓存名称，用于监控指标的标签
+const (
+	NameUserProfile     = "user_profile"
+	NamePointsSummary   = "points_summary"
+	NameActiveCampaigns = "active_campaigns"
+)
+
+var group singleflight.Group
+
+// UserProfileKey 用户信息的缓存 key
+func UserProfileKey(userID int64) string {
+	return fmt.Sprintf(userProfileKeyFormat, userID)
+}
+
+// PointsSummaryKey 用户积分汇总的缓存 key
+func PointsSummaryKey(userID int64) string {
+	return fmt.Sprintf(pointsSummaryKeyFormat, userID)
+}
+
+// ActiveCampaignsKey 进行中和未开始的活动列表的缓存 key，同一个租户的所有用户共用
+func ActiveCampaignsKey(ctx context.Context) string {
+	return tenant.Key(ctx, activeCampaignsKey)
+}
+
+// GetOrLoad 读取缓存，未命中时调用 load 回源并写入缓存，load 返回错误时不缓存
+// 关闭缓存或 ttl 为 0 时直接调用 load
+func GetOrLoad[T any](ctx context.Context, name, key string, ttl time.Duration, load func(ctx context.Context) (*T, error)) (*T, error) {
+	if !conf.Get().Cache.Enabled || ttl <= 0 {
+		return load(ctx)
+	}
+	b, err := dao.RedisClient.Get(ctx, key).Bytes()
+	if err == nil {
+		var v T
+		if err := json.Unmarshal(b, &v); err == nil {
+			metrics.CacheRequestsTotal.WithLabelValues(name, metrics.CacheHit).Inc()
+			return &v, nil
+		}
+		logging.Ctx(ctx).Warn("unmarshal cache value error", zap.String("key", key), zap.Error(err))
+	} else if !errors.Is(err, redis.Nil) {
+		logging.Ctx(ctx).Warn("get cache error", zap.String("key", key), zap.Error(err))
+	}
+	metrics.CacheRequestsTotal.WithLabelValues(name, metrics.CacheMiss).Inc()
+
+	// 同一个 key 的并发请求共用第一个请求的加载结果，加载时去掉取消信号，
+	// 否则第一个请求被取消后所有等待的请求都会返回 context.Canceled
+	loadCtx := context.WithoutCancel(ctx)
+	v, err, _ := group.Do(key, func() (any, error) {
+		ctx := loadCtx
+		v, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			logging.Ctx(ctx).Warn("marshal cache value error", zap.String("key", key), zap.Error(err))
+			return v, nil
+		}
+		if err := dao.RedisClient.Set(ctx, key, b, ttl).Err(); err != nil {
+			logging.Ctx(ctx).Warn("set cache error", zap.String("key", key), zap.Error(err))
+		}
+		return v, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*T), nil
+}
+
+// Delete 删除缓存，在数据库写入成功之后调用
+// 关闭缓存时也会删除，避免重新开启后读到关闭期间没有失效的旧数据
+func Delete(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	// 集群模式下多个 key 可能不在同一个 slot，逐个删除
+	for _, key := range keys {
+		if err := dao.RedisClient.Del(ctx, key).Err(); err != nil {
+			logging.Ctx(ctx).Error("[NEED_HANDLE] delete cache error", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
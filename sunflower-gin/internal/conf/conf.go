@@ -1,20 +1,265 @@
 package conf
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-playground/validator/v10"
 	"github.com/spf13/viper"
+	"go.uber.org/zap"
 )
 
-// Load 加载配置文件，参数是配置文件的路径
-func Load(confPath string) *viper.Viper {
-	conf := viper.New()
-	conf.SetConfigFile(confPath)
+// 配置加载顺序（后面的覆盖前面的）：
+// 1. 默认值
+// 2. 基础配置文件 config.yaml
+// 3. 环境配置文件 config.{env}.yaml，如 config.prod.yaml，不存在时跳过
+// 4. 环境变量 SUNFLOWER_MYSQL_PASSWORD，key 中的 . 替换为 _ 并转为大写
+// 5. 密钥文件 SUNFLOWER_MYSQL_PASSWORD_FILE=/run/secrets/mysql_password，读取文件内容作为配置值
+
+const (
+	EnvPrefix = "SUNFLOWER"
+
+	secretFileSuffix = "_FILE"
+)
+
+var (
+	current atomic.Pointer[Config] // 当前生效的配置
+
+	mu        sync.Mutex
+	loadPath  string              // 基础配置文件路径
+	loadEnv   string              // 环境名称
+	listeners []func(cfg *Config) // 配置热更新后的回调
+)
 
-	err := conf.ReadInConfig() // 读取配置信息
+// MustLoad 加载并校验配置，失败时 panic
+func MustLoad(confPath, env string) *Config {
+	cfg, err := load(confPath, env)
 	if err != nil {
 		panic(err) // 读取配置信息失败时，返回并退出程序
 	}
-	// conf.GetString("server.mode")
-	// conf.GetInt("server.port")
+	mu.Lock()
+	loadPath, loadEnv = confPath, env
+	mu.Unlock()
+	current.Store(cfg)
+	return cfg
+}
+
+// Get 返回当前生效的配置，热更新后返回新的配置
+func Get() *Config {
+	return current.Load()
+}
+
+// OnReload 注册配置热更新后的回调
+func OnReload(fn func(cfg *Config)) {
+	mu.Lock()
+	defer mu.Unlock()
+	listeners = append(listeners, fn)
+}
+
+// Watch 监听配置文件变化并热更新
+// 只有日志级别、限流规则、奖励规则、缓存配置、转赠规则、管理员列表、邀请规则、任务定义、抽奖奖品和租户配置可以在运行时安全修改，其它配置修改后需要重启服务
+func Watch() {
+	mu.Lock()
+	files := []string{loadPath}
+	if overlay := overlayPath(loadPath, loadEnv); fileExists(overlay) {
+		files = append(files, overlay)
+	}
+	mu.Unlock()
+	for _, file := range files {
+		v := viper.New()
+		v.SetConfigFile(file)
+		v.OnConfigChange(func(e fsnotify.Event) { reload(e.Name) })
+		v.WatchConfig()
+	}
+}
+
+// reload 重新加载配置，校验失败时保留原来的配置
+func reload(changed string) {
+	mu.Lock()
+	defer mu.Unlock()
+	cfg, err := load(loadPath, loadEnv)
+	if err != nil {
+		zap.L().Error("reload config failed, keep the current config", zap.String("file", changed), zap.Error(err))
+		return
+	}
+	next := *Get()
+	next.Log.Level = cfg.Log.Level
+	next.RateLimit = cfg.RateLimit
+	next.Reward = cfg.Reward
+	next.Cache = cfg.Cache
+	next.Transfer = cfg.Transfer
+	next.Admin = cfg.Admin
+	next.Referral = cfg.Referral
+	next.Mission = cfg.Mission
+	next.Draw = cfg.Draw
+	next.Tenants = cfg.Tenants
+	current.Store(&next)
+	zap.L().Info("config reloaded", zap.String("file", changed))
+	for _, fn := range listeners {
+		fn(&next)
+	}
+}
+
+func load(confPath, env string) (*Config, error) {
+	v := viper.New()
+	setDefaults(v)
+	v.SetConfigFile(confPath)
+	if err := v.ReadInConfig(); err != nil { // 读取配置信息
+		return nil, fmt.Errorf("read config %s failed, err:%w", confPath, err)
+	}
+	if overlay := overlayPath(confPath, env); fileExists(overlay) {
+		v.SetConfigFile(overlay)
+		if err := v.MergeInConfig(); err != nil {
+			return nil, fmt.Errorf("merge config %s failed, err:%w", overlay, err)
+		}
+	}
+
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	keys := leafKeys(reflect.TypeOf(Config{}), "")
+	for _, key := range keys {
+		// 绑定所有配置项，配置文件中没有的 key 也能通过环境变量设置
+		if err := v.BindEnv(key); err != nil {
+			return nil, err
+		}
+		if err := loadSecretFile(v, key); err != nil {
+			return nil, err
+		}
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unmarshal config failed, err:%w", err)
+	}
+	if err := mergeTenantRewards(v, &cfg); err != nil {
+		return nil, err
+	}
+	if err := validator.New().Struct(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid config, err:%w", err)
+	}
+	if err := checkTenants(cfg.Tenants); err != nil {
+		return nil, fmt.Errorf("invalid config, err:%w", err)
+	}
+	return &cfg, nil
+}
+
+// mergeTenantRewards 租户的奖励规则在全局 reward 的基础上覆盖，只需要配置和全局不同的部分，列表整体替换
+func mergeTenantRewards(v *viper.Viper, cfg *Config) error {
+	tenants, _ := v.Get("tenants").([]any)
+	for i, t := range tenants {
+		m, _ := t.(map[string]any)
+		override, ok := m["reward"].(map[string]any)
+		if !ok || i >= len(cfg.Tenants) {
+			continue
+		}
+		tv := viper.New()
+		if err := tv.MergeConfigMap(map[string]any{"reward": v.AllSettings()["reward"]}); err != nil {
+			return err
+		}
+		if err := tv.MergeConfigMap(map[string]any{"reward": override}); err != nil {
+			return err
+		}
+		var reward RewardConfig
+		if err := tv.UnmarshalKey("reward", &reward); err != nil {
+			return fmt.Errorf("unmarshal tenant %s reward failed, err:%w", cfg.Tenants[i].ID, err)
+		}
+		cfg.Tenants[i].Reward = &reward
+	}
+	return nil
+}
+
+// checkTenants 租户ID、域名、app key 和 JWT issuer 不能重复
+func checkTenants(tenants []TenantConfig) error {
+	ids, hosts, keys, issuers := map[string]bool{}, map[string]bool{}, map[string]bool{}, map[string]bool{}
+	for _, t := range tenants {
+		if ids[t.ID] {
+			return fmt.Errorf("duplicate tenant id %q", t.ID)
+		}
+		ids[t.ID] = true
+		for _, h := range t.Hosts {
+			if hosts[h] {
+				return fmt.Errorf("duplicate tenant host %q", h)
+			}
+			hosts[h] = true
+		}
+		for _, k := range t.AppKeys {
+			if keys[k] {
+				return fmt.Errorf("duplicate tenant app key %q", k)
+			}
+			keys[k] = true
+		}
+		if t.JWTIssuer != "" {
+			if issuers[t.JWTIssuer] {
+				return fmt.Errorf("duplicate tenant jwt issuer %q", t.JWTIssuer)
+			}
+			issuers[t.JWTIssuer] = true
+		}
+	}
+	return nil
+}
 
-	return conf
+// loadSecretFile 环境变量 {KEY}_FILE 指定了密钥文件时，使用文件内容作为配置值
+func loadSecretFile(v *viper.Viper, key string) error {
+	envKey := EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_")) + secretFileSuffix
+	file := os.Getenv(envKey)
+	if file == "" {
+		return nil
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("read secret file %s=%s failed, err:%w", envKey, file, err)
+	}
+	v.Set(key, strings.TrimSpace(string(b)))
+	return nil
+}
+
+// leafKeys 按 mapstructure tag 列出配置结构体的所有叶子节点 key，如 mysql.password
+// map、slice 和 time.Duration 作为整体处理
+func leafKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name := strings.SplitN(f.Tag.Get("mapstructure"), ",", 2)[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && ft != reflect.TypeOf(time.Duration(0)) {
+			keys = append(keys, leafKeys(ft, key)...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// overlayPath 环境配置文件路径，config/config.yaml + prod -> config/config.prod.yaml
+func overlayPath(confPath, env string) string {
+	if env == "" {
+		return ""
+	}
+	ext := filepath.Ext(confPath)
+	return strings.TrimSuffix(confPath, ext) + "." + env + ext
+}
+
+func fileExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
 }
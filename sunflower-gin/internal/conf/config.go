@@ -0,0 +1,286 @@
+package conf
+
+import (
+	"time"
+
+	"sunflower-gin/pkg/jwt"
+	"sunflower-gin/pkg/logging"
+	"sunflower-gin/pkg/snowflake"
+	"sunflower-gin/pkg/tracing"
+
+	"github.com/spf13/viper"
+)
+
+// Config 服务配置，对应 config.yaml 的结构
+type Config struct {
+	Server    ServerConfig     `mapstructure:"server"`
+	Snowflake snowflake.Config `mapstructure:"snowflake"`
+	JWT       jwt.Config       `mapstructure:"jwt"`
+	Log       logging.Config   `mapstructure:"log"`
+	MySQL     MySQLConfig      `mapstructure:"mysql"`
+	Redis     RedisConfig      `mapstructure:"redis"`
+	Task      TaskConfig       `mapstructure:"task"`
+	Tracing   tracing.Config   `mapstructure:"tracing"`
+	RateLimit RateLimitConfig  `mapstructure:"ratelimit"`
+	Reward    RewardConfig     `mapstructure:"reward"`
+	Cache     CacheConfig      `mapstructure:"cache"`
+	Transfer  TransferConfig   `mapstructure:"transfer"`
+	Admin     AdminConfig      `mapstructure:"admin"`
+	Referral  ReferralConfig   `mapstructure:"referral"`
+	Mission   MissionConfig    `mapstructure:"mission"`
+	Draw      DrawConfig       `mapstructure:"draw"`
+	Tenants   []TenantConfig   `mapstructure:"tenants" validate:"dive"`
+	Internal  InternalConfig   `mapstructure:"internal"`
+}
+
+type ServerConfig struct {
+	Name            string        `mapstructure:"name" validate:"required"`
+	Mode            string        `mapstructure:"mode" validate:"oneof=dev test release"`
+	Port            int           `mapstructure:"port" validate:"gt=0,lt=65536"`
+	Version         string        `mapstructure:"version"`
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout" validate:"gt=0"` // 优雅退出时等待处理中请求完成的最长时间
+}
+
+type MySQLConfig struct {
+	Host         string        `mapstructure:"host" validate:"required"`
+	Port         int           `mapstructure:"port" validate:"gt=0,lt=65536"`
+	User         string        `mapstructure:"user" validate:"required"`
+	Password     string        `mapstructure:"password"`
+	DBName       string        `mapstructure:"dbname" validate:"required"`
+	MaxOpenConns int           `mapstructure:"max_open_conns" validate:"gte=0"`
+	MaxIdleConns int           `mapstructure:"max_idle_conns" validate:"gte=0"`
+	MaxLifetime  time.Duration `mapstructure:"max_lifetime" validate:"gte=0"`
+
+	Replicas       []MySQLReplicaConfig `mapstructure:"replicas" validate:"dive"`          // 从库，不配置时读写都走主库
+	ReadYourWrites time.Duration        `mapstructure:"read_your_writes" validate:"gte=0"` // 写操作之后该用户的读请求走主库的时长，0 表示关闭
+}
+
+// MySQLReplicaConfig 从库地址，账号和库名与主库相同
+type MySQLReplicaConfig struct {
+	Host string `mapstructure:"host" validate:"required"`
+	Port int    `mapstructure:"port" validate:"gt=0,lt=65536"`
+}
+
+// Redis 部署模式
+const (
+	RedisModeStandalone = "standalone" // 单节点
+	RedisModeSentinel   = "sentinel"   // 哨兵
+	RedisModeCluster    = "cluster"    // 集群
+)
+
+type RedisConfig struct {
+	Mode             string   `mapstructure:"mode" validate:"oneof=standalone sentinel cluster"`
+	Host             string   `mapstructure:"host" validate:"required_if=Mode standalone"`                // 单节点模式的地址
+	Port             int      `mapstructure:"port" validate:"required_if=Mode standalone,gte=0,lt=65536"` // 单节点模式的端口
+	Addrs            []string `mapstructure:"addrs" validate:"required_unless=Mode standalone"`           // 哨兵地址或集群节点地址
+	MasterName       string   `mapstructure:"master_name" validate:"required_if=Mode sentinel"`           // 哨兵模式的主节点名称
+	SentinelPassword string   `mapstructure:"sentinel_password"`
+	Username         string   `mapstructure:"username"`
+	Password         string   `mapstructure:"password"`
+	DB               int      `mapstructure:"db" validate:"gte=0"` // 集群模式只有 0 号库，配置了也会被忽略
+
+	// 连接池和超时，为 0 时使用 go-redis 的默认值
+	PoolSize     int           `mapstructure:"pool_size" validate:"gte=0"`
+	MinIdleConns int           `mapstructure:"min_idle_conns" validate:"gte=0"`
+	DialTimeout  time.Duration `mapstructure:"dial_timeout" validate:"gte=0"`
+	ReadTimeout  time.Duration `mapstructure:"read_timeout" validate:"gte=0"`
+	WriteTimeout time.Duration `mapstructure:"write_timeout" validate:"gte=0"`
+	PoolTimeout  time.Duration `mapstructure:"pool_timeout" validate:"gte=0"`
+}
+
+type TaskConfig struct {
+	StopTimeout time.Duration       `mapstructure:"stop_timeout" validate:"gt=0"` // 优雅退出时等待正在执行的定时任务完成的最长时间
+	Reconcile   ReconcileTaskConfig `mapstructure:"reconcile"`
+	Missions    MissionTaskConfig   `mapstructure:"missions"`
+}
+
+// ReconcileTaskConfig 积分对账任务
+type ReconcileTaskConfig struct {
+	Spec   string `mapstructure:"spec"`   // CRON 表达式，为空时不执行
+	Repair bool   `mapstructure:"repair"` // 发现余额不一致时是否自动追加校正流水
+}
+
+// MissionTaskConfig 任务周期重置，清理已经结束的每日、每周任务进度
+type MissionTaskConfig struct {
+	Spec           string        `mapstructure:"spec"`                               // CRON 表达式，为空时不执行
+	Retention      time.Duration `mapstructure:"retention" validate:"gt=168h"`       // 结束的周期保留多久，要比最长的周期（一周）长
+	EventRetention time.Duration `mapstructure:"event_retention" validate:"gte=24h"` // 事件记录保留多久，要比上报方重试的时间长，过期后同一个事件ID可以再次上报
+}
+
+// CacheConfig Redis 缓存配置，支持热更新
+type CacheConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	UserProfileTTL   time.Duration `mapstructure:"user_profile_ttl" validate:"gte=0"`   // 用户信息缓存时长
+	PointsSummaryTTL time.Duration `mapstructure:"points_summary_ttl" validate:"gte=0"` // 积分汇总缓存时长
+	CampaignsTTL     time.Duration `mapstructure:"campaigns_ttl" validate:"gte=0"`      // 进行中的活动列表缓存时长
+}
+
+// RateLimitConfig 限流配置，支持热更新
+type RateLimitConfig struct {
+	Enabled bool                     `mapstructure:"enabled"`
+	Groups  map[string]RateLimitRule `mapstructure:"groups" validate:"dive"`
+}
+
+// RateLimitRule 限流规则，表示 Window 时间内最多允许 Limit 次请求
+type RateLimitRule struct {
+	Key    string        `mapstructure:"key" validate:"oneof=ip user route"` // 限流维度
+	Limit  int           `mapstructure:"limit" validate:"gte=0"`             // 窗口内允许的最大请求数
+	Window time.Duration `mapstructure:"window" validate:"gte=0"`            // 窗口大小
+}
+
+// RewardConfig 签到奖励规则，支持热更新
+type RewardConfig struct {
+	DailyPoints           int64                  `mapstructure:"daily_points" validate:"gt=0"`               // 每日签到积分
+	RetroCostPoints       int64                  `mapstructure:"retro_cost_points" validate:"gte=0"`         // 补签消耗积分
+	MaxRetroTimesPerMonth int                    `mapstructure:"max_retro_times_per_month" validate:"gte=0"` // 每月最多补签次数
+	ConsecutiveBonus      []ConsecutiveBonusRule `mapstructure:"consecutive_bonus" validate:"dive"`          // 连续签到奖励
+	Tiers                 []TierRule             `mapstructure:"tiers" validate:"dive"`                      // 用户等级和等级权益
+}
+
+// ConsecutiveBonusRule 连续签到奖励的触发规则
+type ConsecutiveBonusRule struct {
+	BonusType   int32 `mapstructure:"bonus_type" validate:"oneof=1 2 3 4"` // 奖励类型 1:3天 2:7天 3:15天 4:月度满签
+	TriggerDays int   `mapstructure:"trigger_days" validate:"gt=0,lte=31"` // 需要连续签到多少天才能触发这个规则
+	Points      int64 `mapstructure:"points" validate:"gt=0"`              // 发放的积分数量
+}
+
+// TierRule 用户等级规则，累计获得的积分或最长连续签到天数满足任一条件即可达到这个等级，两个条件都为 0 时所有用户都满足
+type TierRule struct {
+	Level             int32  `mapstructure:"level" validate:"gt=0"`                // 等级，数字越大等级越高
+	Name              string `mapstructure:"name" validate:"required"`             // 等级编码，展示名称的多语言 key 为 tier.{name}
+	MinLifetimePoints int64  `mapstructure:"min_lifetime_points" validate:"gte=0"` // 累计获得的积分达到这个数量，0 表示不按积分计算
+	MinStreakDays     int    `mapstructure:"min_streak_days" validate:"gte=0"`     // 最长连续签到天数达到这个天数，0 表示不按连续签到计算
+	DailyPointsBonus  int64  `mapstructure:"daily_points_bonus" validate:"gte=0"`  // 每日签到额外获得的积分
+	ExtraRetroTimes   int    `mapstructure:"extra_retro_times" validate:"gte=0"`   // 每月额外的补签次数
+}
+
+// TransferConfig 积分转赠规则，支持热更新
+type TransferConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	MinAmount        int64         `mapstructure:"min_amount" validate:"gt=0"`          // 单笔最少转赠积分
+	DailyLimitAmount int64         `mapstructure:"daily_limit_amount" validate:"gte=0"` // 每天最多转出的积分，0 表示不限制
+	DailyLimitCount  int           `mapstructure:"daily_limit_count" validate:"gte=0"`  // 每天最多转出的次数，0 表示不限制
+	ConfirmThreshold int64         `mapstructure:"confirm_threshold" validate:"gte=0"`  // 单笔达到这个数量时需要输入密码确认，0 表示不需要确认
+	ConfirmTTL       time.Duration `mapstructure:"confirm_ttl" validate:"gt=0"`         // 待确认的转赠多久之后过期
+}
+
+// ReferralConfig 邀请奖励规则，支持热更新
+type ReferralConfig struct {
+	Enabled              bool          `mapstructure:"enabled"`                                  // 关闭后注册时忽略邀请码，等待中的邀请关系暂停发放奖励
+	RequiredCheckins     int32         `mapstructure:"required_checkins" validate:"gt=0"`        // 被邀请人完成这么多次每日签到后发放奖励
+	InviterPoints        int64         `mapstructure:"inviter_points" validate:"gte=0"`          // 邀请人获得的奖励积分
+	InviteePoints        int64         `mapstructure:"invitee_points" validate:"gte=0"`          // 被邀请人获得的奖励积分
+	MaxRewardsPerInviter int           `mapstructure:"max_rewards_per_inviter" validate:"gte=0"` // 每个邀请人最多获得多少次奖励，0 表示不限制
+	AbuseWindow          time.Duration `mapstructure:"abuse_window" validate:"gt=0"`             // 统计同一IP、同一设备注册次数的时间窗口
+	MaxPerIP             int           `mapstructure:"max_per_ip" validate:"gte=0"`              // 窗口内同一IP最多可以绑定多少个邀请关系，超过的不发放奖励，0 表示不限制
+	MaxPerDevice         int           `mapstructure:"max_per_device" validate:"gte=0"`          // 窗口内同一设备最多可以绑定多少个邀请关系，超过的不发放奖励，0 表示不限制
+}
+
+// 任务周期
+const (
+	MissionPeriodDaily  = "daily"  // 每日任务，每天 0 点重置
+	MissionPeriodWeekly = "weekly" // 每周任务，每周一 0 点重置
+	MissionPeriodOnce   = "once"   // 一次性任务，不重置
+)
+
+// MissionConfig 任务规则，支持热更新
+type MissionConfig struct {
+	Definitions []MissionRule `mapstructure:"definitions" validate:"dive"`
+}
+
+// MissionRule 任务定义，其它服务上报 Event 事件累计 Target 次后完成任务，领取 Points 积分
+// 已经上线的任务不能修改 Code，名称的多语言 key 为 mission.{code}
+type MissionRule struct {
+	Code   string `mapstructure:"code" validate:"required,max=32"`
+	Period string `mapstructure:"period" validate:"oneof=daily weekly once"`
+	Event  string `mapstructure:"event" validate:"required,max=32"`
+	Target int64  `mapstructure:"target" validate:"gt=0"`
+	Points int64  `mapstructure:"points" validate:"gt=0"`
+}
+
+// 抽奖奖品类型
+const (
+	DrawPrizePoints    = "points"     // 积分
+	DrawPrizeRetroCard = "retro_card" // 补签卡，补签时不消耗积分
+	DrawPrizeCoupon    = "coupon"     // 优惠券，发放一个券码，由其它服务核销
+	DrawPrizeNone      = "none"       // 谢谢参与
+)
+
+// DrawConfig 幸运抽奖规则，支持热更新
+type DrawConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	CouponTTL time.Duration `mapstructure:"coupon_ttl" validate:"gt=0"` // 优惠券的有效期
+	Prizes    []DrawPrize   `mapstructure:"prizes" validate:"dive"`
+}
+
+// DrawPrize 奖品定义，抽中的概率为 Weight 除以所有有库存的奖品的权重之和
+// 已经上线的奖品不能修改 Code，名称的多语言 key 为 draw.prize.{code}
+type DrawPrize struct {
+	Code   string `mapstructure:"code" validate:"required,max=32"`
+	Type   string `mapstructure:"type" validate:"oneof=points retro_card coupon none"`
+	Amount int64  `mapstructure:"amount" validate:"gte=0"` // 积分数量、补签卡张数或优惠券面额
+	Weight int64  `mapstructure:"weight" validate:"gt=0"`
+	Stock  int64  `mapstructure:"stock" validate:"gte=0"` // 总库存，0 表示不限制
+}
+
+// TenantConfig 租户配置，一个服务同时运行多个应用的签到时，每个应用是一个租户，数据和 Redis key 按租户隔离
+// 没有配置的请求属于默认租户 default，默认租户也可以出现在列表中，用来设置域名和 JWT issuer
+type TenantConfig struct {
+	ID        string        `mapstructure:"id" validate:"required,max=32,alphanum"`
+	Hosts     []string      `mapstructure:"hosts"`      // 按请求的 Host 识别租户，不含端口
+	AppKeys   []string      `mapstructure:"app_keys"`   // 按请求头 X-App-Key 识别租户，客户端内置的应用标识，不用于认证
+	JWTIssuer string        `mapstructure:"jwt_issuer"` // 签发和校验 JWT 的 issuer，为空时使用 {jwt.issuer}/{id}，默认租户为 jwt.issuer，租户之间的 token 不能混用
+	Reward    *RewardConfig `mapstructure:"reward"`     // 租户的奖励规则，在全局 reward 的基础上覆盖，为空时使用全局规则
+}
+
+// InternalConfig 内部接口配置，供其它服务调用
+// 内部接口都使用 API key 签名认证
+type InternalConfig struct {
+	SignatureTTL    time.Duration `mapstructure:"signature_ttl" validate:"gt=0"`      // 签名请求的时间戳与服务器时间允许的最大误差，nonce 在 2 倍时长内不能重复
+	MaxCreditPoints int64         `mapstructure:"max_credit_points" validate:"gte=0"` // 单次发放积分的上限，0 表示不限制
+}
+
+// AdminConfig 管理后台配置，支持热更新
+type AdminConfig struct {
+	UserIDs []int64 `mapstructure:"user_ids"` // 可以访问管理接口的用户ID
+}
+
+// setDefaults 默认配置
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("server.name", "sunflower")
+	v.SetDefault("server.mode", "dev")
+	v.SetDefault("server.port", 8000)
+	v.SetDefault("server.shutdown_timeout", 15*time.Second)
+	v.SetDefault("task.stop_timeout", 30*time.Second)
+	v.SetDefault("task.missions.retention", 30*24*time.Hour)
+	v.SetDefault("task.missions.event_retention", 90*24*time.Hour)
+	v.SetDefault("log.level", "info")
+	v.SetDefault("redis.mode", RedisModeStandalone)
+	v.SetDefault("cache.user_profile_ttl", 10*time.Minute)
+	v.SetDefault("cache.points_summary_ttl", 5*time.Minute)
+	v.SetDefault("cache.campaigns_ttl", time.Minute)
+	v.SetDefault("transfer.min_amount", 1)
+	v.SetDefault("transfer.confirm_ttl", 10*time.Minute)
+	v.SetDefault("referral.required_checkins", 3)
+	v.SetDefault("referral.abuse_window", 24*time.Hour)
+	v.SetDefault("draw.coupon_ttl", 30*24*time.Hour)
+	v.SetDefault("jwt.issuer", "liwenzhou.com")
+	v.SetDefault("internal.signature_ttl", 5*time.Minute)
+	v.SetDefault("tracing.exporter", tracing.ExporterOTLP)
+	v.SetDefault("tracing.sample_ratio", 1.0)
+
+	v.SetDefault("reward.daily_points", 1)
+	v.SetDefault("reward.retro_cost_points", 100)
+	v.SetDefault("reward.max_retro_times_per_month", 3)
+	v.SetDefault("reward.consecutive_bonus", []map[string]any{
+		{"bonus_type": 1, "trigger_days": 3, "points": 5},
+		{"bonus_type": 2, "trigger_days": 7, "points": 10},
+		{"bonus_type": 3, "trigger_days": 15, "points": 20},
+		{"bonus_type": 4, "trigger_days": 28, "points": 100},
+	})
+	v.SetDefault("reward.tiers", []map[string]any{
+		{"level": 1, "name": "bronze"},
+		{"level": 2, "name": "silver", "min_lifetime_points": 500, "min_streak_days": 30, "daily_points_bonus": 1, "extra_retro_times": 1},
+		{"level": 3, "name": "gold", "min_lifetime_points": 3000, "min_streak_days": 100, "daily_points_bonus": 2, "extra_retro_times": 2},
+	})
+}
@@ -2,56 +2,94 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"sunflower-gin/internal/conf"
 	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/metrics"
+	"sunflower-gin/pkg/tracing"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
-	"github.com/spf13/viper"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
 var (
 	DB          *gorm.DB
-	RedisClient *redis.Client
+	RedisClient redis.UniversalClient
 )
 
 // MustInitMySQL 初始化 MySQL 连接
-func MustInitMySQL(cfg *viper.Viper) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		cfg.GetString("mysql.user"),
-		cfg.GetString("mysql.password"),
-		cfg.GetString("mysql.host"),
-		cfg.GetString("mysql.port"),
-		cfg.GetString("mysql.dbname"),
-	)
-	db, err := gorm.Open(mysql.Open(dsn))
+func MustInitMySQL(cfg *conf.MySQLConfig) {
+	db, err := gorm.Open(mysql.Open(mysqlDSN(cfg, cfg.Host, cfg.Port)))
 	if err != nil {
 		panic(fmt.Errorf("connect db fail: %w", err))
 	}
+	// 配置了从库时开启读写分离，需要在其它插件之前注册，否则打开从库连接时会把已注册的插件再初始化一遍
+	if len(cfg.Replicas) > 0 {
+		if err := db.Use(newResolver(cfg)); err != nil {
+			panic(fmt.Errorf("use gorm dbresolver plugin fail: %w", err))
+		}
+		stickyWindow = cfg.ReadYourWrites
+	}
+	if err := db.Use(TenantPlugin{}); err != nil { // 按租户隔离数据
+		panic(fmt.Errorf("use gorm tenant plugin fail: %w", err))
+	}
+	if err := db.Use(metrics.GormPlugin{}); err != nil { // 统计 SQL 执行耗时
+		panic(fmt.Errorf("use gorm metrics plugin fail: %w", err))
+	}
+	if err := db.Use(tracing.GormPlugin{}); err != nil { // 链路追踪
+		panic(fmt.Errorf("use gorm tracing plugin fail: %w", err))
+	}
 
 	sqlDB, err := db.DB()
 	if err != nil {
 		panic(fmt.Errorf("connect db fail: %w", err))
 	}
-	// 设置连接池参数
-	sqlDB.SetMaxIdleConns(cfg.GetInt("mysql.max_idle_conns"))
-	sqlDB.SetMaxOpenConns(cfg.GetInt("mysql.max_open_conns"))
-	sqlDB.SetConnMaxLifetime(cfg.GetDuration("mysql.max_lifetime"))
+	// 设置主库连接池参数，从库的连接池参数在 newResolver 中设置
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(cfg.MaxLifetime)
 
+	DB = db
 	query.SetDefault(db) // 指定 query 包使用的默认数据库连接
 }
 
-// MustInitRedis 初始化 Redis 连接
-func MustInitRedis(conf *viper.Viper) {
-	addr := fmt.Sprintf("%s:%d", conf.GetString("redis.host"), conf.GetInt("redis.port"))
-	rdb := redis.NewClient(&redis.Options{
-		Addr:     addr,
-		Password: conf.GetString("redis.password"),
-		DB:       conf.GetInt("redis.db"),
-	})
+// MustInitRedis 初始化 Redis 连接，支持单节点、哨兵和集群模式
+// 集群模式下多个 key 的操作需要落在同一个 slot，key 中相同的部分用 {} 包起来，如 user:checkins:daily:{123}:2025
+func MustInitRedis(cfg *conf.RedisConfig) {
+	opts := &redis.UniversalOptions{
+		Addrs:            cfg.Addrs,
+		MasterName:       cfg.MasterName,
+		SentinelPassword: cfg.SentinelPassword,
+		Username:         cfg.Username,
+		Password:         cfg.Password,
+		DB:               cfg.DB,
+		PoolSize:         cfg.PoolSize,
+		MinIdleConns:     cfg.MinIdleConns,
+		DialTimeout:      cfg.DialTimeout,
+		ReadTimeout:      cfg.ReadTimeout,
+		WriteTimeout:     cfg.WriteTimeout,
+		PoolTimeout:      cfg.PoolTimeout,
+	}
+	// 按配置的模式创建客户端，不依赖 NewUniversalClient 根据地址个数推断
+	var rdb redis.UniversalClient
+	switch cfg.Mode {
+	case conf.RedisModeCluster:
+		rdb = redis.NewClusterClient(opts.Cluster())
+	case conf.RedisModeSentinel:
+		rdb = redis.NewFailoverClient(opts.Failover())
+	default:
+		opts.Addrs = []string{fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)}
+		rdb = redis.NewClient(opts.Simple())
+	}
+	rdb.AddHook(metrics.RedisHook{})                         // 统计 Redis 命令耗时
+	if err := redisotel.InstrumentTracing(rdb); err != nil { // 链路追踪
+		panic(fmt.Errorf("init redis tracing failed, err:%w", err))
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -61,3 +99,23 @@ func MustInitRedis(conf *viper.Viper) {
 	}
 	RedisClient = rdb
 }
+
+// Close 关闭 MySQL 和 Redis 连接，服务退出时调用
+func Close() error {
+	var errs []error
+	if DB != nil {
+		sqlDB, err := DB.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("close mysql failed, err:%w", err))
+		}
+	}
+	if RedisClient != nil {
+		if err := RedisClient.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close redis failed, err:%w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
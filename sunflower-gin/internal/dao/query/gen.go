@@ -17,29 +17,83 @@ import (
 
 var (
 	Q                     = new(Query)
+	APIKey                *aPIKey
+	Campaign              *campaign
+	CampaignUserProgress  *campaignUserProgress
+	DrawPrizeStock        *drawPrizeStock
+	LedgerAccount         *ledgerAccount
+	LedgerEntry           *ledgerEntry
+	LedgerPosting         *ledgerPosting
+	LuckyDraw             *luckyDraw
+	MissionEvent          *missionEvent
+	PointsCredit          *pointsCredit
+	PointsTransfer        *pointsTransfer
+	Referral              *referral
+	ReferralCode          *referralCode
+	UserAchievement       *userAchievement
 	UserCheckinRecord     *userCheckinRecord
+	UserMission           *userMission
 	UserMonthlyBonusLog   *userMonthlyBonusLog
 	UserPoint             *userPoint
 	UserPointsTransaction *userPointsTransaction
+	UserRetroCard         *userRetroCard
+	UserTier              *userTier
+	UserTierHistory       *userTierHistory
 	Userinfo              *userinfo
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
+	APIKey = &Q.APIKey
+	Campaign = &Q.Campaign
+	CampaignUserProgress = &Q.CampaignUserProgress
+	DrawPrizeStock = &Q.DrawPrizeStock
+	LedgerAccount = &Q.LedgerAccount
+	LedgerEntry = &Q.LedgerEntry
+	LedgerPosting = &Q.LedgerPosting
+	LuckyDraw = &Q.LuckyDraw
+	MissionEvent = &Q.MissionEvent
+	PointsCredit = &Q.PointsCredit
+	PointsTransfer = &Q.PointsTransfer
+	Referral = &Q.Referral
+	ReferralCode = &Q.ReferralCode
+	UserAchievement = &Q.UserAchievement
 	UserCheckinRecord = &Q.UserCheckinRecord
+	UserMission = &Q.UserMission
 	UserMonthlyBonusLog = &Q.UserMonthlyBonusLog
 	UserPoint = &Q.UserPoint
 	UserPointsTransaction = &Q.UserPointsTransaction
+	UserRetroCard = &Q.UserRetroCard
+	UserTier = &Q.UserTier
+	UserTierHistory = &Q.UserTierHistory
 	Userinfo = &Q.Userinfo
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
 		db:                    db,
+		APIKey:                newAPIKey(db, opts...),
+		Campaign:              newCampaign(db, opts...),
+		CampaignUserProgress:  newCampaignUserProgress(db, opts...),
+		DrawPrizeStock:        newDrawPrizeStock(db, opts...),
+		LedgerAccount:         newLedgerAccount(db, opts...),
+		LedgerEntry:           newLedgerEntry(db, opts...),
+		LedgerPosting:         newLedgerPosting(db, opts...),
+		LuckyDraw:             newLuckyDraw(db, opts...),
+		MissionEvent:          newMissionEvent(db, opts...),
+		PointsCredit:          newPointsCredit(db, opts...),
+		PointsTransfer:        newPointsTransfer(db, opts...),
+		Referral:              newReferral(db, opts...),
+		ReferralCode:          newReferralCode(db, opts...),
+		UserAchievement:       newUserAchievement(db, opts...),
 		UserCheckinRecord:     newUserCheckinRecord(db, opts...),
+		UserMission:           newUserMission(db, opts...),
 		UserMonthlyBonusLog:   newUserMonthlyBonusLog(db, opts...),
 		UserPoint:             newUserPoint(db, opts...),
 		UserPointsTransaction: newUserPointsTransaction(db, opts...),
+		UserRetroCard:         newUserRetroCard(db, opts...),
+		UserTier:              newUserTier(db, opts...),
+		UserTierHistory:       newUserTierHistory(db, opts...),
 		Userinfo:              newUserinfo(db, opts...),
 	}
 }
@@ -47,10 +101,28 @@ func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 type Query struct {
 	db *gorm.DB
 
+	APIKey                aPIKey
+	Campaign              campaign
+	CampaignUserProgress  campaignUserProgress
+	DrawPrizeStock        drawPrizeStock
+	LedgerAccount         ledgerAccount
+	LedgerEntry           ledgerEntry
+	LedgerPosting         ledgerPosting
+	LuckyDraw             luckyDraw
+	MissionEvent          missionEvent
+	PointsCredit          pointsCredit
+	PointsTransfer        pointsTransfer
+	Referral              referral
+	ReferralCode          referralCode
+	UserAchievement       userAchievement
 	UserCheckinRecord     userCheckinRecord
+	UserMission           userMission
 	UserMonthlyBonusLog   userMonthlyBonusLog
 	UserPoint             userPoint
 	UserPointsTransaction userPointsTransaction
+	UserRetroCard         userRetroCard
+	UserTier              userTier
+	UserTierHistory       userTierHistory
 	Userinfo              userinfo
 }
 
@@ -59,10 +131,28 @@ func (q *Query) Available() bool { return q.db != nil }
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
 		db:                    db,
+		APIKey:                q.APIKey.clone(db),
+		Campaign:              q.Campaign.clone(db),
+		CampaignUserProgress:  q.CampaignUserProgress.clone(db),
+		DrawPrizeStock:        q.DrawPrizeStock.clone(db),
+		LedgerAccount:         q.LedgerAccount.clone(db),
+		LedgerEntry:           q.LedgerEntry.clone(db),
+		LedgerPosting:         q.LedgerPosting.clone(db),
+		LuckyDraw:             q.LuckyDraw.clone(db),
+		MissionEvent:          q.MissionEvent.clone(db),
+		PointsCredit:          q.PointsCredit.clone(db),
+		PointsTransfer:        q.PointsTransfer.clone(db),
+		Referral:              q.Referral.clone(db),
+		ReferralCode:          q.ReferralCode.clone(db),
+		UserAchievement:       q.UserAchievement.clone(db),
 		UserCheckinRecord:     q.UserCheckinRecord.clone(db),
+		UserMission:           q.UserMission.clone(db),
 		UserMonthlyBonusLog:   q.UserMonthlyBonusLog.clone(db),
 		UserPoint:             q.UserPoint.clone(db),
 		UserPointsTransaction: q.UserPointsTransaction.clone(db),
+		UserRetroCard:         q.UserRetroCard.clone(db),
+		UserTier:              q.UserTier.clone(db),
+		UserTierHistory:       q.UserTierHistory.clone(db),
 		Userinfo:              q.Userinfo.clone(db),
 	}
 }
@@ -78,28 +168,82 @@ func (q *Query) WriteDB() *Query {
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
 		db:                    db,
+		APIKey:                q.APIKey.replaceDB(db),
+		Campaign:              q.Campaign.replaceDB(db),
+		CampaignUserProgress:  q.CampaignUserProgress.replaceDB(db),
+		DrawPrizeStock:        q.DrawPrizeStock.replaceDB(db),
+		LedgerAccount:         q.LedgerAccount.replaceDB(db),
+		LedgerEntry:           q.LedgerEntry.replaceDB(db),
+		LedgerPosting:         q.LedgerPosting.replaceDB(db),
+		LuckyDraw:             q.LuckyDraw.replaceDB(db),
+		MissionEvent:          q.MissionEvent.replaceDB(db),
+		PointsCredit:          q.PointsCredit.replaceDB(db),
+		PointsTransfer:        q.PointsTransfer.replaceDB(db),
+		Referral:              q.Referral.replaceDB(db),
+		ReferralCode:          q.ReferralCode.replaceDB(db),
+		UserAchievement:       q.UserAchievement.replaceDB(db),
 		UserCheckinRecord:     q.UserCheckinRecord.replaceDB(db),
+		UserMission:           q.UserMission.replaceDB(db),
 		UserMonthlyBonusLog:   q.UserMonthlyBonusLog.replaceDB(db),
 		UserPoint:             q.UserPoint.replaceDB(db),
 		UserPointsTransaction: q.UserPointsTransaction.replaceDB(db),
+		UserRetroCard:         q.UserRetroCard.replaceDB(db),
+		UserTier:              q.UserTier.replaceDB(db),
+		UserTierHistory:       q.UserTierHistory.replaceDB(db),
 		Userinfo:              q.Userinfo.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
+	APIKey                IAPIKeyDo
+	Campaign              ICampaignDo
+	CampaignUserProgress  ICampaignUserProgressDo
+	DrawPrizeStock        IDrawPrizeStockDo
+	LedgerAccount         ILedgerAccountDo
+	LedgerEntry           ILedgerEntryDo
+	LedgerPosting         ILedgerPostingDo
+	LuckyDraw             ILuckyDrawDo
+	MissionEvent          IMissionEventDo
+	PointsCredit          IPointsCreditDo
+	PointsTransfer        IPointsTransferDo
+	Referral              IReferralDo
+	ReferralCode          IReferralCodeDo
+	UserAchievement       IUserAchievementDo
 	UserCheckinRecord     IUserCheckinRecordDo
+	UserMission           IUserMissionDo
 	UserMonthlyBonusLog   IUserMonthlyBonusLogDo
 	UserPoint             IUserPointDo
 	UserPointsTransaction IUserPointsTransactionDo
+	UserRetroCard         IUserRetroCardDo
+	UserTier              IUserTierDo
+	UserTierHistory       IUserTierHistoryDo
 	Userinfo              IUserinfoDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
+		APIKey:                q.APIKey.WithContext(ctx),
+		Campaign:              q.Campaign.WithContext(ctx),
+		CampaignUserProgress:  q.CampaignUserProgress.WithContext(ctx),
+		DrawPrizeStock:        q.DrawPrizeStock.WithContext(ctx),
+		LedgerAccount:         q.LedgerAccount.WithContext(ctx),
+		LedgerEntry:           q.LedgerEntry.WithContext(ctx),
+		LedgerPosting:         q.LedgerPosting.WithContext(ctx),
+		LuckyDraw:             q.LuckyDraw.WithContext(ctx),
+		MissionEvent:          q.MissionEvent.WithContext(ctx),
+		PointsCredit:          q.PointsCredit.WithContext(ctx),
+		PointsTransfer:        q.PointsTransfer.WithContext(ctx),
+		Referral:              q.Referral.WithContext(ctx),
+		ReferralCode:          q.ReferralCode.WithContext(ctx),
+		UserAchievement:       q.UserAchievement.WithContext(ctx),
 		UserCheckinRecord:     q.UserCheckinRecord.WithContext(ctx),
+		UserMission:           q.UserMission.WithContext(ctx),
 		UserMonthlyBonusLog:   q.UserMonthlyBonusLog.WithContext(ctx),
 		UserPoint:             q.UserPoint.WithContext(ctx),
 		UserPointsTransaction: q.UserPointsTransaction.WithContext(ctx),
+		UserRetroCard:         q.UserRetroCard.WithContext(ctx),
+		UserTier:              q.UserTier.WithContext(ctx),
+		UserTierHistory:       q.UserTierHistory.WithContext(ctx),
 		Userinfo:              q.Userinfo.WithContext(ctx),
 	}
 }
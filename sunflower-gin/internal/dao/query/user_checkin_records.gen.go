@@ -29,6 +29,7 @@ func newUserCheckinRecord(db *gorm.DB, opts ...gen.DOOption) userCheckinRecord {
 	tableName := _userCheckinRecord.userCheckinRecordDo.TableName()
 	_userCheckinRecord.ALL = field.NewAsterisk(tableName)
 	_userCheckinRecord.ID = field.NewInt64(tableName, "id")
+	_userCheckinRecord.TenantID = field.NewString(tableName, "tenant_id")
 	_userCheckinRecord.UserID = field.NewInt64(tableName, "user_id")
 	_userCheckinRecord.CheckinDate = field.NewTime(tableName, "checkin_date")
 	_userCheckinRecord.CheckinType = field.NewInt32(tableName, "checkin_type")
@@ -46,8 +47,9 @@ type userCheckinRecord struct {
 	userCheckinRecordDo userCheckinRecordDo
 
 	ALL               field.Asterisk
-	ID                field.Int64 // ID
-	UserID            field.Int64 // ID
+	ID                field.Int64  // ID
+	TenantID          field.String // 租户ID
+	UserID            field.Int64  // ID
 	CheckinDate       field.Time
 	CheckinType       field.Int32 // 1=2=
 	PointsAwardedBase field.Int32
@@ -71,6 +73,7 @@ func (u userCheckinRecord) As(alias string) *userCheckinRecord {
 func (u *userCheckinRecord) updateTableName(table string) *userCheckinRecord {
 	u.ALL = field.NewAsterisk(table)
 	u.ID = field.NewInt64(table, "id")
+	u.TenantID = field.NewString(table, "tenant_id")
 	u.UserID = field.NewInt64(table, "user_id")
 	u.CheckinDate = field.NewTime(table, "checkin_date")
 	u.CheckinType = field.NewInt32(table, "checkin_type")
@@ -106,8 +109,9 @@ func (u *userCheckinRecord) GetFieldByName(fieldName string) (field.OrderExpr, b
 }
 
 func (u *userCheckinRecord) fillFieldMap() {
-	u.fieldMap = make(map[string]field.Expr, 8)
+	u.fieldMap = make(map[string]field.Expr, 9)
 	u.fieldMap["id"] = u.ID
+	u.fieldMap["tenant_id"] = u.TenantID
 	u.fieldMap["user_id"] = u.UserID
 	u.fieldMap["checkin_date"] = u.CheckinDate
 	u.fieldMap["checkin_type"] = u.CheckinType
@@ -29,6 +29,7 @@ func newUserMonthlyBonusLog(db *gorm.DB, opts ...gen.DOOption) userMonthlyBonusL
 	tableName := _userMonthlyBonusLog.userMonthlyBonusLogDo.TableName()
 	_userMonthlyBonusLog.ALL = field.NewAsterisk(tableName)
 	_userMonthlyBonusLog.ID = field.NewInt64(tableName, "id")
+	_userMonthlyBonusLog.TenantID = field.NewString(tableName, "tenant_id")
 	_userMonthlyBonusLog.UserID = field.NewInt64(tableName, "user_id")
 	_userMonthlyBonusLog.YearMonth = field.NewString(tableName, "year_month")
 	_userMonthlyBonusLog.BonusType = field.NewInt32(tableName, "bonus_type")
@@ -47,6 +48,7 @@ type userMonthlyBonusLog struct {
 
 	ALL         field.Asterisk
 	ID          field.Int64  // ID
+	TenantID    field.String // 租户ID
 	UserID      field.Int64  // ID
 	YearMonth   field.String // YYYYMM
 	BonusType   field.Int32  // 1:3 2:7 3:15 4:
@@ -71,6 +73,7 @@ func (u userMonthlyBonusLog) As(alias string) *userMonthlyBonusLog {
 func (u *userMonthlyBonusLog) updateTableName(table string) *userMonthlyBonusLog {
 	u.ALL = field.NewAsterisk(table)
 	u.ID = field.NewInt64(table, "id")
+	u.TenantID = field.NewString(table, "tenant_id")
 	u.UserID = field.NewInt64(table, "user_id")
 	u.YearMonth = field.NewString(table, "year_month")
 	u.BonusType = field.NewInt32(table, "bonus_type")
@@ -106,8 +109,9 @@ func (u *userMonthlyBonusLog) GetFieldByName(fieldName string) (field.OrderExpr,
 }
 
 func (u *userMonthlyBonusLog) fillFieldMap() {
-	u.fieldMap = make(map[string]field.Expr, 8)
+	u.fieldMap = make(map[string]field.Expr, 9)
 	u.fieldMap["id"] = u.ID
+	u.fieldMap["tenant_id"] = u.TenantID
 	u.fieldMap["user_id"] = u.UserID
 	u.fieldMap["year_month"] = u.YearMonth
 	u.fieldMap["bonus_type"] = u.BonusType
@@ -29,6 +29,7 @@ func newUserPoint(db *gorm.DB, opts ...gen.DOOption) userPoint {
 	tableName := _userPoint.userPointDo.TableName()
 	_userPoint.ALL = field.NewAsterisk(tableName)
 	_userPoint.ID = field.NewInt64(tableName, "id")
+	_userPoint.TenantID = field.NewString(tableName, "tenant_id")
 	_userPoint.UserID = field.NewInt64(tableName, "user_id")
 	_userPoint.Points = field.NewInt64(tableName, "points")
 	_userPoint.PointsTotal = field.NewInt64(tableName, "points_total")
@@ -45,8 +46,9 @@ type userPoint struct {
 	userPointDo userPointDo
 
 	ALL         field.Asterisk
-	ID          field.Int64 // ID
-	UserID      field.Int64 // ID
+	ID          field.Int64  // ID
+	TenantID    field.String // 租户ID
+	UserID      field.Int64  // ID
 	Points      field.Int64
 	PointsTotal field.Int64
 	CreatedAt   field.Time
@@ -69,6 +71,7 @@ func (u userPoint) As(alias string) *userPoint {
 func (u *userPoint) updateTableName(table string) *userPoint {
 	u.ALL = field.NewAsterisk(table)
 	u.ID = field.NewInt64(table, "id")
+	u.TenantID = field.NewString(table, "tenant_id")
 	u.UserID = field.NewInt64(table, "user_id")
 	u.Points = field.NewInt64(table, "points")
 	u.PointsTotal = field.NewInt64(table, "points_total")
@@ -101,8 +104,9 @@ func (u *userPoint) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (u *userPoint) fillFieldMap() {
-	u.fieldMap = make(map[string]field.Expr, 7)
+	u.fieldMap = make(map[string]field.Expr, 8)
 	u.fieldMap["id"] = u.ID
+	u.fieldMap["tenant_id"] = u.TenantID
 	u.fieldMap["user_id"] = u.UserID
 	u.fieldMap["points"] = u.Points
 	u.fieldMap["points_total"] = u.PointsTotal
@@ -29,6 +29,7 @@ func newUserPointsTransaction(db *gorm.DB, opts ...gen.DOOption) userPointsTrans
 	tableName := _userPointsTransaction.userPointsTransactionDo.TableName()
 	_userPointsTransaction.ALL = field.NewAsterisk(tableName)
 	_userPointsTransaction.ID = field.NewInt64(tableName, "id")
+	_userPointsTransaction.TenantID = field.NewString(tableName, "tenant_id")
 	_userPointsTransaction.UserID = field.NewInt64(tableName, "user_id")
 	_userPointsTransaction.PointsChange = field.NewInt64(tableName, "points_change")
 	_userPointsTransaction.CurrentBalance = field.NewInt64(tableName, "current_balance")
@@ -48,9 +49,10 @@ type userPointsTransaction struct {
 	userPointsTransactionDo userPointsTransactionDo
 
 	ALL             field.Asterisk
-	ID              field.Int64 // ID
-	UserID          field.Int64 // ID
-	PointsChange    field.Int64 //  ()
+	ID              field.Int64  // ID
+	TenantID        field.String // 租户ID
+	UserID          field.Int64  // ID
+	PointsChange    field.Int64  //  ()
 	CurrentBalance  field.Int64
 	TransactionType field.Int32 // (1: 2: 3: 4: 5:)
 	Description     field.String
@@ -75,6 +77,7 @@ func (u userPointsTransaction) As(alias string) *userPointsTransaction {
 func (u *userPointsTransaction) updateTableName(table string) *userPointsTransaction {
 	u.ALL = field.NewAsterisk(table)
 	u.ID = field.NewInt64(table, "id")
+	u.TenantID = field.NewString(table, "tenant_id")
 	u.UserID = field.NewInt64(table, "user_id")
 	u.PointsChange = field.NewInt64(table, "points_change")
 	u.CurrentBalance = field.NewInt64(table, "current_balance")
@@ -112,8 +115,9 @@ func (u *userPointsTransaction) GetFieldByName(fieldName string) (field.OrderExp
 }
 
 func (u *userPointsTransaction) fillFieldMap() {
-	u.fieldMap = make(map[string]field.Expr, 10)
+	u.fieldMap = make(map[string]field.Expr, 11)
 	u.fieldMap["id"] = u.ID
+	u.fieldMap["tenant_id"] = u.TenantID
 	u.fieldMap["user_id"] = u.UserID
 	u.fieldMap["points_change"] = u.PointsChange
 	u.fieldMap["current_balance"] = u.CurrentBalance
@@ -29,6 +29,7 @@ func newUserinfo(db *gorm.DB, opts ...gen.DOOption) userinfo {
 	tableName := _userinfo.userinfoDo.TableName()
 	_userinfo.ALL = field.NewAsterisk(tableName)
 	_userinfo.ID = field.NewInt64(tableName, "id")
+	_userinfo.TenantID = field.NewString(tableName, "tenant_id")
 	_userinfo.UserID = field.NewInt64(tableName, "user_id")
 	_userinfo.Username = field.NewString(tableName, "username")
 	_userinfo.Password = field.NewString(tableName, "password")
@@ -47,8 +48,9 @@ type userinfo struct {
 	userinfoDo userinfoDo
 
 	ALL       field.Asterisk
-	ID        field.Int64 // ID
-	UserID    field.Int64 // ID
+	ID        field.Int64  // ID
+	TenantID  field.String // 租户ID
+	UserID    field.Int64  // ID
 	Username  field.String
 	Password  field.String // (MD5)
 	Email     field.String
@@ -73,6 +75,7 @@ func (u userinfo) As(alias string) *userinfo {
 func (u *userinfo) updateTableName(table string) *userinfo {
 	u.ALL = field.NewAsterisk(table)
 	u.ID = field.NewInt64(table, "id")
+	u.TenantID = field.NewString(table, "tenant_id")
 	u.UserID = field.NewInt64(table, "user_id")
 	u.Username = field.NewString(table, "username")
 	u.Password = field.NewString(table, "password")
@@ -105,8 +108,9 @@ func (u *userinfo) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (u *userinfo) fillFieldMap() {
-	u.fieldMap = make(map[string]field.Expr, 9)
+	u.fieldMap = make(map[string]field.Expr, 10)
 	u.fieldMap["id"] = u.ID
+	u.fieldMap["tenant_id"] = u.TenantID
 	u.fieldMap["user_id"] = u.UserID
 	u.fieldMap["username"] = u.Username
 	u.fieldMap["password"] = u.Password
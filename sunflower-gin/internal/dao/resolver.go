@@ -0,0 +1,79 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/pkg/logging"
+
+	"go.uber.org/zap"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// 读写分离
+// 1. Query/Row 和 SELECT 开头的 Raw SQL 走从库，Create/Update/Delete 和 FOR UPDATE 走主库
+// 2. 事务从主库开启，事务内的所有 SQL 都在主库执行
+// 3. 先读后写的场景（如读出积分再加上奖励后保存）需要用 WriteDB() 显式指定主库
+// 4. 写操作之后调用 MarkWritten，该用户在 read_your_writes 时间内的读请求走主库
+
+const (
+	stickyPrimaryKeyFormat = "user:sticky_primary:%d" // user:sticky_primary:123213131
+)
+
+var stickyWindow time.Duration // 写后读主库的时长，0 表示关闭
+
+// mysqlDSN 拼接 MySQL 连接串，从库使用与主库相同的账号和库名
+func mysqlDSN(cfg *conf.MySQLConfig, host string, port int) string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		cfg.User,
+		cfg.Password,
+		host,
+		port,
+		cfg.DBName,
+	)
+}
+
+// newResolver 创建读写分离插件，主库沿用 gorm.Open 打开的连接，多个从库随机选择
+func newResolver(cfg *conf.MySQLConfig) gorm.Plugin {
+	replicas := make([]gorm.Dialector, 0, len(cfg.Replicas))
+	for _, r := range cfg.Replicas {
+		replicas = append(replicas, mysql.Open(mysqlDSN(cfg, r.Host, r.Port)))
+	}
+	return dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}).
+		SetMaxIdleConns(cfg.MaxIdleConns).
+		SetMaxOpenConns(cfg.MaxOpenConns).
+		SetConnMaxLifetime(cfg.MaxLifetime)
+}
+
+// MarkWritten 标记用户刚刚写入过数据，接下来一段时间内该用户的读请求走主库
+// 标记失败只影响读到的数据是否最新，不影响写操作本身，所以只记录日志
+func MarkWritten(ctx context.Context, userID int64) {
+	if stickyWindow <= 0 {
+		return
+	}
+	key := fmt.Sprintf(stickyPrimaryKeyFormat, userID)
+	if err := RedisClient.Set(ctx, key, 1, stickyWindow).Err(); err != nil {
+		logging.Ctx(ctx).Warn("mark user written error", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// ReadPrimary 用户最近是否写入过数据，是的话读请求应该走主库
+func ReadPrimary(ctx context.Context, userID int64) bool {
+	if stickyWindow <= 0 {
+		return false
+	}
+	key := fmt.Sprintf(stickyPrimaryKeyFormat, userID)
+	n, err := RedisClient.Exists(ctx, key).Result()
+	if err != nil {
+		logging.Ctx(ctx).Warn("check user written error", zap.String("key", key), zap.Error(err))
+		return false
+	}
+	return n > 0
+}
@@ -0,0 +1,73 @@
+package dao
+
+import (
+	"errors"
+	"reflect"
+
+	"sunflower-gin/internal/tenant"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GORM 插件，按 ctx 中的租户隔离数据
+// 查询、更新和删除时自动加上 tenant_id 条件，创建时自动填充 tenant_id，没有 tenant_id 字段的表不处理
+
+const (
+	tenantColumn       = "tenant_id"
+	tenantCallbackName = "tenant"
+)
+
+// TenantPlugin 多租户数据隔离的 GORM 插件
+type TenantPlugin struct{}
+
+func (TenantPlugin) Name() string {
+	return "tenant"
+}
+
+// Initialize 在每类操作之前注册 callback
+func (TenantPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register(tenantCallbackName+":create", fillTenant),
+		cb.Query().Before("gorm:query").Register(tenantCallbackName+":query", scopeTenant),
+		cb.Update().Before("gorm:update").Register(tenantCallbackName+":update", scopeTenant),
+		cb.Delete().Before("gorm:delete").Register(tenantCallbackName+":delete", scopeTenant),
+		cb.Row().Before("gorm:row").Register(tenantCallbackName+":row", scopeTenant),
+	)
+}
+
+// scopeTenant 加上当前租户的过滤条件
+func scopeTenant(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil || db.Statement.Schema.LookUpField(tenantColumn) == nil {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: db.Statement.Table, Name: tenantColumn}, Value: tenant.FromContext(db.Statement.Context)},
+	}})
+}
+
+// fillTenant 新记录的 tenant_id 设置为当前租户，支持批量创建
+func fillTenant(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+	field := db.Statement.Schema.LookUpField(tenantColumn)
+	if field == nil {
+		return
+	}
+	ctx, id := db.Statement.Context, tenant.FromContext(db.Statement.Context)
+	switch rv := db.Statement.ReflectValue; rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range rv.Len() {
+			if err := field.Set(ctx, reflect.Indirect(rv.Index(i)), id); err != nil {
+				db.AddError(err)
+				return
+			}
+		}
+	case reflect.Struct:
+		if err := field.Set(ctx, rv, id); err != nil {
+			db.AddError(err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package achievement
+
+import (
+	"time"
+
+	"sunflower-gin/api"
+	v1 "sunflower-gin/api/achievement/v1"
+	"sunflower-gin/internal/middleware"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/achievement"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListHandler 当前用户已获得和未获得的成就
+func ListHandler(c *gin.Context) {
+	// 1. 获取当前用户
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	// 2. 调用 service 层查询成就
+	output, err := achievement.List(c, userID)
+	if err != nil {
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
+		return
+	}
+	// 3. 按是否获得分组返回
+	resp := &v1.AchievementListResp{
+		Earned: make([]*v1.AchievementInfo, 0, len(output)),
+		Locked: make([]*v1.AchievementInfo, 0, len(output)),
+	}
+	for _, item := range output {
+		if item.UnlockedAt != nil {
+			resp.Earned = append(resp.Earned, toAchievementInfo(item))
+		} else {
+			resp.Locked = append(resp.Locked, toAchievementInfo(item))
+		}
+	}
+	api.ResponseSuccess(c, resp)
+}
+
+// NotificationListHandler 新获得还没有查看的成就
+func NotificationListHandler(c *gin.Context) {
+	// 1. 获取当前用户
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	// 2. 调用 service 层查询通知
+	output, err := achievement.Notifications(c, userID)
+	if err != nil {
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
+		return
+	}
+	// 3. 返回通知列表
+	list := make([]*v1.AchievementInfo, 0, len(output))
+	for _, item := range output {
+		list = append(list, toAchievementInfo(item))
+	}
+	api.ResponseSuccess(c, &v1.NotificationListResp{List: list})
+}
+
+// AckNotificationsHandler 标记成就通知已查看，之后不再出现在通知列表中
+func AckNotificationsHandler(c *gin.Context) {
+	// 1. 获取请求参数和当前用户
+	var req v1.AckNotificationsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	// 2. 调用 service 层标记已查看
+	if err := achievement.Ack(c, userID, req.Codes); err != nil {
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
+		return
+	}
+	// 3. 返回响应
+	api.ResponseSuccess(c, &v1.AckNotificationsResp{})
+}
+
+func toAchievementInfo(info *model.AchievementInfo) *v1.AchievementInfo {
+	resp := &v1.AchievementInfo{
+		Code:        info.Code,
+		Name:        info.Name,
+		Description: info.Description,
+		Target:      info.Target,
+		Progress:    info.Progress,
+	}
+	if info.UnlockedAt != nil {
+		resp.UnlockedTime = info.UnlockedAt.Format(time.DateTime)
+	}
+	return resp
+}
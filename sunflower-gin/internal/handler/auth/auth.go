@@ -5,6 +5,7 @@ import (
 	v1 "sunflower-gin/api/auth/v1"
 	"sunflower-gin/internal/model"
 	"sunflower-gin/internal/service/auth"
+	"sunflower-gin/pkg/logging"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -15,8 +16,8 @@ func LoginHandler(c *gin.Context) {
 	// 1. 获取请求参数并进行参数校验
 	var req v1.LoginReq
 	if err := c.ShouldBindJSON(&req); err != nil {
-		zap.L().Error("参数校验失败", zap.Error(err))
-		api.ResponseError(c, api.CodeInvalidParam)
+		logging.Ctx(c).Error("参数校验失败", zap.Error(err))
+		api.ResponseInvalidParam(c, err)
 		return
 	}
 	// 2. 调用用户登录服务
@@ -25,8 +26,8 @@ func LoginHandler(c *gin.Context) {
 		Password: req.Password,
 	})
 	if err != nil {
-		zap.L().Error("用户登录失败", zap.Error(err))
-		api.ResponseErrorWithMsg(c, api.CodeInvalidPassword, err.Error())
+		logging.Ctx(c).Error("用户登录失败", zap.Error(err))
+		api.ResponseErrorWithErr(c, api.CodeInvalidPassword, err)
 		return
 	}
 	// 3. 拼装响应数据并返回
@@ -40,15 +41,15 @@ func RefreshHandler(c *gin.Context) {
 	// 1. 获取请求参数并进行参数校验
 	var req v1.RefreshReq
 	if err := c.ShouldBindJSON(&req); err != nil {
-		zap.L().Error("参数校验失败", zap.Error(err))
-		api.ResponseError(c, api.CodeInvalidParam)
+		logging.Ctx(c).Error("参数校验失败", zap.Error(err))
+		api.ResponseInvalidParam(c, err)
 		return
 	}
 	// 2. 调用service层刷新token
 	output, err := auth.RefreshToken(c, req.RefreshToken)
 	if err != nil {
-		zap.L().Error("刷新token失败", zap.Error(err))
-		api.ResponseErrorWithMsg(c, api.CodeInvalidToken, err.Error())
+		logging.Ctx(c).Error("刷新token失败", zap.Error(err))
+		api.ResponseErrorWithErr(c, api.CodeInvalidToken, err)
 		return
 	}
 	// 3. 拼装响应数据并返回
@@ -11,7 +11,7 @@ func AddHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req v1.Req
 		if err := c.ShouldBindJSON(&req); err != nil {
-			api.ResponseError(c, api.CodeInvalidParam)
+			api.ResponseInvalidParam(c, err)
 			return
 		}
 		ret := v1.Resp{
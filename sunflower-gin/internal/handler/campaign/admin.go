@@ -0,0 +1,181 @@
+package campaign
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"sunflower-gin/api"
+	v1 "sunflower-gin/api/campaign/v1"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/campaign"
+	"sunflower-gin/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultLimit = 20  // 默认分页大小
+	maxLimit     = 100 // 最大分页大小
+)
+
+// CreateHandler 管理后台创建活动
+func CreateHandler(c *gin.Context) {
+	// 1. 获取请求参数
+	var req v1.CampaignReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	// 2. 调用 service 层创建活动
+	output, err := campaign.Create(c, toInput(&req))
+	if err != nil {
+		api.ResponseErrorWithErr(c, campaignErrCode(err), err)
+		return
+	}
+	// 3. 返回活动信息
+	api.ResponseSuccess(c, toCampaignInfo(output))
+}
+
+// UpdateHandler 管理后台修改活动
+func UpdateHandler(c *gin.Context) {
+	// 1. 获取请求参数
+	var req v1.CampaignReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		api.ResponseError(c, api.CodeInvalidParam)
+		return
+	}
+	input := toInput(&req)
+	input.ID = id
+	// 2. 调用 service 层修改活动
+	output, err := campaign.Update(c, input)
+	if err != nil {
+		api.ResponseErrorWithErr(c, campaignErrCode(err), err)
+		return
+	}
+	// 3. 返回活动信息
+	api.ResponseSuccess(c, toCampaignInfo(output))
+}
+
+// DeleteHandler 管理后台删除活动，活动奖池的余额退回系统
+func DeleteHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		api.ResponseError(c, api.CodeInvalidParam)
+		return
+	}
+	if err := campaign.Delete(c, id); err != nil {
+		api.ResponseErrorWithErr(c, campaignErrCode(err), err)
+		return
+	}
+	api.ResponseSuccess(c, &v1.DeleteCampaignResp{})
+}
+
+// GetHandler 管理后台查询活动详情
+func GetHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		api.ResponseError(c, api.CodeInvalidParam)
+		return
+	}
+	output, err := campaign.Get(c, id)
+	if err != nil {
+		api.ResponseErrorWithErr(c, campaignErrCode(err), err)
+		return
+	}
+	api.ResponseSuccess(c, toCampaignInfo(output))
+}
+
+// ListHandler 管理后台分页查询活动
+func ListHandler(c *gin.Context) {
+	// 1. 获取分页信息
+	var req v1.CampaignListReq
+	if err := c.ShouldBind(&req); err != nil {
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	if req.Limit <= 0 || req.Limit > maxLimit {
+		req.Limit = defaultLimit
+	}
+	// 2. 调用 service 层查询活动
+	output, err := campaign.List(c, &model.CampaignListInput{
+		Status: model.CampaignStatus(req.Status),
+		Offset: max(req.Offset, 0),
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		api.ResponseErrorWithErr(c, campaignErrCode(err), err)
+		return
+	}
+	// 3. 返回活动列表
+	list := make([]*v1.CampaignInfo, 0, len(output.List))
+	for _, item := range output.List {
+		list = append(list, toCampaignInfo(item))
+	}
+	api.ResponseSuccess(c, &v1.CampaignListResp{
+		Total: output.Total,
+		List:  list,
+	})
+}
+
+// campaignErrCode 活动相关错误对应的业务错误码，可翻译的错误都是业务校验不通过
+func campaignErrCode(err error) api.ResCode {
+	var e *i18n.Error
+	if errors.As(err, &e) {
+		return api.CodeInvalidParam
+	}
+	return api.CodeServerBusy
+}
+
+func toInput(req *v1.CampaignReq) *model.CampaignInput {
+	// 格式已经在参数校验时检查过
+	startAt, _ := time.ParseInLocation(time.DateTime, req.StartTime, time.Local)
+	endAt, _ := time.ParseInLocation(time.DateTime, req.EndTime, time.Local)
+	status := model.CampaignStatusDisabled
+	if req.Enabled {
+		status = model.CampaignStatusEnabled
+	}
+	return &model.CampaignInput{
+		Name:        req.Name,
+		Type:        model.CampaignType(req.Type),
+		Status:      status,
+		StartAt:     startAt,
+		EndAt:       endAt,
+		Multiplier:  req.Multiplier,
+		GoalDays:    req.GoalDays,
+		BonusPoints: req.BonusPoints,
+		Budget:      req.Budget,
+		Rules: model.CampaignRules{
+			Weekdays:          req.Rules.Weekdays,
+			NewUserDays:       req.Rules.NewUserDays,
+			MinLifetimePoints: req.Rules.MinLifetimePoints,
+		},
+	}
+}
+
+func toCampaignInfo(info *model.CampaignInfo) *v1.CampaignInfo {
+	return &v1.CampaignInfo{
+		ID:              info.ID,
+		Name:            info.Name,
+		Type:            int32(info.Type),
+		Enabled:         info.Status == model.CampaignStatusEnabled,
+		StartTime:       info.StartAt.Format(time.DateTime),
+		EndTime:         info.EndAt.Format(time.DateTime),
+		Multiplier:      info.Multiplier,
+		GoalDays:        info.GoalDays,
+		BonusPoints:     info.BonusPoints,
+		Budget:          info.Budget,
+		RemainingBudget: info.RemainingBudget,
+		Rules: v1.CampaignRules{
+			Weekdays:          info.Rules.Weekdays,
+			NewUserDays:       info.Rules.NewUserDays,
+			MinLifetimePoints: info.Rules.MinLifetimePoints,
+		},
+		CreatedTime: info.CreatedAt.Format(time.DateTime),
+	}
+}
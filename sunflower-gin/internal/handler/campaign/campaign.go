@@ -0,0 +1,45 @@
+package campaign
+
+import (
+	"time"
+
+	"sunflower-gin/api"
+	v1 "sunflower-gin/api/campaign/v1"
+	"sunflower-gin/internal/middleware"
+	"sunflower-gin/internal/service/campaign"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserListHandler 当前用户可以参与的进行中的活动和目标进度
+func UserListHandler(c *gin.Context) {
+	// 1. 获取当前用户
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	// 2. 调用 service 层查询活动
+	output, err := campaign.UserList(c, userID)
+	if err != nil {
+		api.ResponseErrorWithErr(c, campaignErrCode(err), err)
+		return
+	}
+	// 3. 返回活动列表
+	list := make([]*v1.UserCampaignInfo, 0, len(output))
+	for _, item := range output {
+		list = append(list, &v1.UserCampaignInfo{
+			ID:          item.ID,
+			Name:        item.Name,
+			Type:        int32(item.Type),
+			StartTime:   item.StartAt.Format(time.DateTime),
+			EndTime:     item.EndAt.Format(time.DateTime),
+			Multiplier:  item.Multiplier,
+			GoalDays:    item.GoalDays,
+			BonusPoints: item.BonusPoints,
+			CheckinDays: item.CheckinDays,
+			Completed:   item.Completed,
+		})
+	}
+	api.ResponseSuccess(c, &v1.UserCampaignListResp{List: list})
+}
@@ -14,7 +14,7 @@ func CalendarHandler(c *gin.Context) {
 	// 1. 获取请求参数并校验 userID
 	var req v1.CalendarReq
 	if err := c.ShouldBindQuery(&req); err != nil {
-		api.ResponseError(c, api.CodeInvalidParam)
+		api.ResponseInvalidParam(c, err)
 		return
 	}
 	userID := c.Value(middleware.CtxKeyUserID).(int64)
@@ -27,7 +27,7 @@ func CalendarHandler(c *gin.Context) {
 	// 2. 调用 service 层处理业务
 	output, err := checkin.MonthDetail(c, userID, t)
 	if err != nil {
-		api.ResponseErrorWithMsg(c, api.CodeServerBusy, err.Error())
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
 		return
 	}
 	// 3. 返回响应
@@ -39,6 +39,7 @@ func CalendarHandler(c *gin.Context) {
 			RetroCheckedInDays: output.RetroCheckedInDays,
 			IsCheckedInToday:   output.IsCheckedInToday,
 			RemainRetroTimes:   output.RemainRetroTimes,
+			RetroCards:         output.RetroCards,
 			ConsecutiveDays:    output.ConsecutiveDays,
 		},
 	})
@@ -20,7 +20,7 @@ func DailyHandler(c *gin.Context) {
 	// 2. 调用 service 层处理业务
 	err := checkin.Daily(c, userID)
 	if err != nil {
-		api.ResponseErrorWithMsg(c, api.CodeServerBusy, err.Error())
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
 		return
 	}
 	// 3. 返回响应
@@ -15,7 +15,7 @@ func RetroactiveHandler(c *gin.Context) {
 	// 1. 获取请求参数和当前用户
 	var req v1.RetroReq
 	if err := c.ShouldBindJSON(&req); err != nil {
-		api.ResponseError(c, api.CodeInvalidParam)
+		api.ResponseInvalidParam(c, err)
 		return
 	}
 	userID := c.Value(middleware.CtxKeyUserID).(int64)
@@ -31,7 +31,7 @@ func RetroactiveHandler(c *gin.Context) {
 	}
 	// 2. 调用service层补签逻辑
 	if err := checkin.Retroactive(c, userID, t); err != nil {
-		api.ResponseErrorWithMsg(c, api.CodeServerBusy, err.Error())
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
 		return
 	}
 	// 3. 返回响应
@@ -0,0 +1,45 @@
+package checkin
+
+import (
+	"strconv"
+
+	"sunflower-gin/api"
+	v1 "sunflower-gin/api/checkin/v1"
+	"sunflower-gin/internal/service/checkin"
+	"sunflower-gin/internal/service/user"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatusHandler 内部接口，其它服务查询用户的签到状态
+func StatusHandler(c *gin.Context) {
+	// 1. 获取请求参数
+	userID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil || userID <= 0 {
+		api.ResponseError(c, api.CodeInvalidParam)
+		return
+	}
+	// 2. 校验用户，只能查询当前租户的用户
+	exists, err := user.Exists(c, userID)
+	if err != nil {
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
+		return
+	}
+	if !exists {
+		api.ResponseErrorWithErr(c, api.CodeUserNotExist, user.ErrUserNotExist)
+		return
+	}
+	// 3. 调用 service 层查询签到状态
+	output, err := checkin.Status(c, userID)
+	if err != nil {
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
+		return
+	}
+	// 4. 返回响应
+	api.ResponseSuccess(c, &v1.StatusResp{
+		UserID:           userID,
+		IsCheckedInToday: output.IsCheckedInToday,
+		ConsecutiveDays:  output.ConsecutiveDays,
+		MonthDays:        output.MonthDays,
+	})
+}
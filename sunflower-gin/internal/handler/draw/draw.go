@@ -0,0 +1,144 @@
+package draw
+
+import (
+	"errors"
+	"time"
+
+	"sunflower-gin/api"
+	v1 "sunflower-gin/api/draw/v1"
+	"sunflower-gin/internal/middleware"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/draw"
+	"sunflower-gin/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultLimit = 20  // 默认分页大小
+	maxLimit     = 100 // 最大分页大小
+)
+
+// DrawHandler 今天签到后抽奖
+func DrawHandler(c *gin.Context) {
+	// 1. 获取当前用户
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	// 2. 调用 service 层抽奖
+	output, err := draw.Draw(c, userID)
+	if err != nil {
+		api.ResponseErrorWithErr(c, drawErrCode(err), err)
+		return
+	}
+	// 3. 返回抽奖结果
+	api.ResponseSuccess(c, &v1.DrawResp{DrawInfo: *toDrawInfo(output)})
+}
+
+// PoolHandler 奖品池和今天的抽奖状态
+func PoolHandler(c *gin.Context) {
+	// 1. 获取当前用户
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	// 2. 调用 service 层查询奖品池
+	output, err := draw.Pool(c, userID)
+	if err != nil {
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
+		return
+	}
+	// 3. 返回奖品池
+	prizes := make([]*v1.PrizeInfo, 0, len(output.Prizes))
+	for _, p := range output.Prizes {
+		prizes = append(prizes, &v1.PrizeInfo{
+			Code:        p.Code,
+			Name:        p.Name,
+			Type:        p.Type.String(),
+			Amount:      p.Amount,
+			Probability: p.Probability,
+			Stock:       p.Stock,
+			Remaining:   p.Remaining,
+		})
+	}
+	res := &v1.PoolResp{
+		Enabled:    output.Enabled,
+		CanDraw:    output.CanDraw,
+		RetroCards: output.RetroCard,
+		Prizes:     prizes,
+	}
+	if output.Today != nil {
+		res.Today = toDrawInfo(output.Today)
+	}
+	api.ResponseSuccess(c, res)
+}
+
+// ListHandler 当前用户的抽奖记录
+func ListHandler(c *gin.Context) {
+	// 1. 获取分页信息和当前用户
+	var req v1.DrawListReq
+	if err := c.ShouldBind(&req); err != nil {
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	if req.Limit <= 0 || req.Limit > maxLimit {
+		req.Limit = defaultLimit
+	}
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	// 2. 调用 service 层查询抽奖记录
+	output, err := draw.List(c, &model.DrawListInput{
+		UserID: userID,
+		Offset: max(req.Offset, 0),
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
+		return
+	}
+	// 3. 返回抽奖记录
+	list := make([]*v1.DrawInfo, 0, len(output.List))
+	for _, item := range output.List {
+		list = append(list, toDrawInfo(item))
+	}
+	api.ResponseSuccess(c, &v1.DrawListResp{
+		Total: output.Total,
+		List:  list,
+	})
+}
+
+// drawErrCode 抽奖相关错误对应的业务错误码，可翻译的错误都是业务校验不通过
+func drawErrCode(err error) api.ResCode {
+	var e *i18n.Error
+	if errors.As(err, &e) {
+		return api.CodeInvalidParam
+	}
+	return api.CodeServerBusy
+}
+
+func toDrawInfo(info *model.DrawInfo) *v1.DrawInfo {
+	res := &v1.DrawInfo{
+		ID:          info.ID,
+		DrawDate:    info.DrawDate.Format(time.DateOnly),
+		PrizeCode:   info.PrizeCode,
+		PrizeName:   info.PrizeName,
+		PrizeType:   info.PrizeType.String(),
+		Amount:      info.Amount,
+		CouponCode:  info.CouponCode,
+		ServerSeed:  info.ServerSeed,
+		Nonce:       info.Nonce,
+		Roll:        info.Roll,
+		TotalWeight: info.TotalWeight,
+		CreatedTime: info.CreatedAt.Format(time.DateTime),
+	}
+	if info.CouponExpiresAt != nil {
+		res.CouponExpireTime = info.CouponExpiresAt.Format(time.DateTime)
+	}
+	return res
+}
@@ -0,0 +1,60 @@
+package health
+
+import (
+	"net/http"
+
+	v1 "sunflower-gin/api/health/v1"
+	"sunflower-gin/internal/service/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 健康检查接口，供 k8s 等编排系统探测使用，直接通过 HTTP 状态码表示结果
+
+// LivezHandler 存活探针，进程能处理请求即认为存活，不检查外部依赖
+func LivezHandler(version string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, &v1.HealthResp{
+			Status:  health.StatusUp,
+			Version: version,
+		})
+	}
+}
+
+// HealthzHandler 健康检查，检查所有依赖，任一依赖异常返回 503
+func HealthzHandler(version string) gin.HandlerFunc {
+	return checkHandler(version, health.CheckMySQL, health.CheckRedis, health.CheckCron)
+}
+
+// ReadyzHandler 就绪探针，MySQL 和 Redis 都可用时才接收流量
+func ReadyzHandler(version string) gin.HandlerFunc {
+	return checkHandler(version, health.CheckMySQL, health.CheckRedis)
+}
+
+func checkHandler(version string, names ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		results := health.Check(c, names...)
+		resp := &v1.HealthResp{
+			Status:  health.StatusUp,
+			Version: version,
+			Checks:  make(map[string]v1.CheckResult, len(results)),
+		}
+		for name, ret := range results {
+			item := v1.CheckResult{
+				Status:  health.StatusUp,
+				Latency: ret.Latency.String(),
+			}
+			if !ret.Up {
+				item.Status = health.StatusDown
+				item.Error = ret.Err.Error()
+				resp.Status = health.StatusDown
+			}
+			resp.Checks[name] = item
+		}
+		status := http.StatusOK
+		if resp.Status != health.StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, resp)
+	}
+}
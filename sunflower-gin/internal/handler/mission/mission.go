@@ -0,0 +1,113 @@
+package mission
+
+import (
+	"errors"
+	"time"
+
+	"sunflower-gin/api"
+	v1 "sunflower-gin/api/mission/v1"
+	"sunflower-gin/internal/middleware"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/mission"
+	"sunflower-gin/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListHandler 所有任务和当前用户在当前周期的进度
+func ListHandler(c *gin.Context) {
+	// 1. 获取当前用户
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	// 2. 调用 service 层查询任务
+	output, err := mission.List(c, userID)
+	if err != nil {
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
+		return
+	}
+	// 3. 返回任务列表
+	list := make([]*v1.MissionInfo, 0, len(output))
+	for _, item := range output {
+		list = append(list, toMissionInfo(item))
+	}
+	api.ResponseSuccess(c, &v1.MissionListResp{List: list})
+}
+
+// ClaimHandler 领取当前周期已经完成的任务奖励
+func ClaimHandler(c *gin.Context) {
+	// 1. 获取当前用户
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	// 2. 调用 service 层领取奖励
+	output, err := mission.Claim(c, userID, c.Param("code"))
+	if err != nil {
+		api.ResponseErrorWithErr(c, missionErrCode(err), err)
+		return
+	}
+	// 3. 返回领取的积分
+	api.ResponseSuccess(c, &v1.ClaimResp{
+		Code:   output.Code,
+		Points: output.Points,
+	})
+}
+
+// ReportEventHandler 内部接口，其它服务上报用户的任务事件
+func ReportEventHandler(c *gin.Context) {
+	// 1. 获取请求参数
+	var req v1.ReportEventReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	// 2. 调用 service 层累加任务进度
+	output, err := mission.Report(c, &model.MissionEventInput{
+		EventID: req.EventID,
+		UserID:  req.UserID,
+		Event:   req.Event,
+		Count:   max(req.Count, 1),
+	})
+	if err != nil {
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
+		return
+	}
+	// 3. 返回处理结果
+	api.ResponseSuccess(c, &v1.ReportEventResp{
+		Duplicate: output.Duplicate,
+		Completed: output.Completed,
+	})
+}
+
+// missionErrCode 任务相关错误对应的业务错误码，可翻译的错误都是业务校验不通过
+func missionErrCode(err error) api.ResCode {
+	var e *i18n.Error
+	if errors.As(err, &e) {
+		return api.CodeInvalidParam
+	}
+	return api.CodeServerBusy
+}
+
+func toMissionInfo(info *model.MissionInfo) *v1.MissionInfo {
+	res := &v1.MissionInfo{
+		Code:      info.Code,
+		Name:      info.Name,
+		Period:    info.Period,
+		Target:    info.Target,
+		Points:    info.Points,
+		Progress:  info.Progress,
+		Completed: info.CompletedAt != nil,
+		Claimed:   info.ClaimedAt != nil,
+	}
+	if info.CompletedAt != nil {
+		res.CompletedTime = info.CompletedAt.Format(time.DateTime)
+	}
+	if info.ResetAt != nil {
+		res.ResetTime = info.ResetAt.Format(time.DateTime)
+	}
+	return res
+}
@@ -0,0 +1,66 @@
+package points
+
+import (
+	"errors"
+
+	"sunflower-gin/api"
+	v1 "sunflower-gin/api/points/v1"
+	"sunflower-gin/internal/middleware"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/points"
+	"sunflower-gin/internal/service/user"
+	"sunflower-gin/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreditHandler 内部接口，其它服务给用户发放积分
+func CreditHandler(c *gin.Context) {
+	// 1. 获取请求参数和调用方
+	var req v1.CreditReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	apiKeyID := c.GetInt64(middleware.CtxKeyAPIKeyID)
+	// 2. 校验用户，只能给当前租户的用户发放
+	exists, err := user.Exists(c, req.UserID)
+	if err != nil {
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
+		return
+	}
+	if !exists {
+		api.ResponseErrorWithErr(c, api.CodeUserNotExist, user.ErrUserNotExist)
+		return
+	}
+	// 3. 调用 service 层发放积分
+	output, err := points.Credit(c, &model.PointsCreditInput{
+		APIKeyID:  apiKeyID,
+		RequestID: req.RequestID,
+		UserID:    req.UserID,
+		Points:    req.Points,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		api.ResponseErrorWithErr(c, creditErrCode(err), err)
+		return
+	}
+	// 4. 返回发放结果
+	api.ResponseSuccess(c, &v1.CreditResp{
+		CreditID:  output.CreditID,
+		Points:    output.Points,
+		Duplicate: output.Duplicate,
+	})
+}
+
+// creditErrCode 发放积分相关错误对应的业务错误码，请求ID冲突返回 CodeConflict，其它可翻译的错误都是业务校验不通过
+func creditErrCode(err error) api.ResCode {
+	if errors.Is(err, points.ErrCreditConflict) {
+		return api.CodeConflict
+	}
+	var e *i18n.Error
+	if errors.As(err, &e) {
+		return api.CodeInvalidParam
+	}
+	return api.CodeServerBusy
+}
@@ -0,0 +1,199 @@
+package points
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"sunflower-gin/api"
+	v1 "sunflower-gin/api/points/v1"
+	"sunflower-gin/internal/middleware"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/points"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xuri/excelize/v2"
+	"go.uber.org/zap"
+)
+
+// 导出格式
+const (
+	exportFormatCSV  = "csv"
+	exportFormatXLSX = "xlsx"
+)
+
+const exportFlushRows = 500 // CSV 每写多少行刷一次到客户端
+
+// 导出文件的列，表头按请求语言翻译
+var exportHeaderKeys = []string{
+	"export.points.time",
+	"export.points.type",
+	"export.points.change",
+	"export.points.balance",
+	"export.points.description",
+}
+
+// exportWriter 按行写入导出文件
+type exportWriter interface {
+	Write(row []any) error
+	Close() error // 写完所有行之后调用，把剩余数据写到响应中
+}
+
+// ExportHandler 导出积分记录，边查边写，不会把所有记录加载到内存中
+func ExportHandler(c *gin.Context) {
+	// 1. 获取当前用户信息和导出参数
+	var req v1.ExportReq
+	if err := c.ShouldBind(&req); err != nil {
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	if req.Format == "" {
+		req.Format = exportFormatCSV
+	}
+	input := &model.ExportInput{UserID: userID}
+	// 日期范围转换为 [StartTime, EndTime) 的时间范围，格式已经由 binding 校验过
+	if req.StartDate != "" {
+		input.StartTime, _ = time.ParseInLocation(time.DateOnly, req.StartDate, time.Local)
+	}
+	if req.EndDate != "" {
+		endDate, _ := time.ParseInLocation(time.DateOnly, req.EndDate, time.Local)
+		input.EndTime = endDate.AddDate(0, 0, 1)
+	}
+	if !input.StartTime.IsZero() && !input.EndTime.IsZero() && !input.StartTime.Before(input.EndTime) {
+		api.ResponseError(c, api.CodeInvalidParam)
+		return
+	}
+
+	// 2. 写响应头和表头，之后出错只能中断响应，无法再返回 JSON 错误信息
+	var w exportWriter
+	switch req.Format {
+	case exportFormatXLSX:
+		c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		w = newXLSXExportWriter(c.Writer)
+	default:
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		w = newCSVExportWriter(c.Writer)
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, points.ExportFilename(input, req.Format)))
+	c.Status(http.StatusOK)
+
+	lang := i18n.FromContext(c)
+	header := make([]any, 0, len(exportHeaderKeys))
+	for _, key := range exportHeaderKeys {
+		header = append(header, i18n.T(lang, key))
+	}
+	err := w.Write(header)
+	// 3. 逐行写入积分记录
+	if err == nil {
+		err = points.Export(c, input, func(row *model.ExportRow) error {
+			return w.Write([]any{
+				row.TransactionTime.Format(time.DateTime),
+				row.TransactionType,
+				row.PointsChange,
+				row.CurrentBalance,
+				row.Description,
+			})
+		})
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		logging.Ctx(c).Error("export points records failed", zap.String("format", req.Format), zap.Error(err))
+		c.Abort()
+	}
+}
+
+// csvExportWriter CSV 格式，每写满一批就刷到客户端
+type csvExportWriter struct {
+	out  gin.ResponseWriter
+	w    *csv.Writer
+	rows int
+}
+
+func newCSVExportWriter(out gin.ResponseWriter) *csvExportWriter {
+	return &csvExportWriter{out: out, w: csv.NewWriter(out)}
+}
+
+func (cw *csvExportWriter) Write(row []any) error {
+	if cw.rows == 0 {
+		// 写入 UTF-8 BOM，Excel 打开时中文才不会乱码
+		if _, err := cw.out.WriteString("\xEF\xBB\xBF"); err != nil {
+			return err
+		}
+	}
+	record := make([]string, 0, len(row))
+	for _, v := range row {
+		record = append(record, fmt.Sprint(v))
+	}
+	if err := cw.w.Write(record); err != nil {
+		return err
+	}
+	cw.rows++
+	if cw.rows%exportFlushRows == 0 {
+		return cw.flush()
+	}
+	return nil
+}
+
+func (cw *csvExportWriter) Close() error {
+	return cw.flush()
+}
+
+func (cw *csvExportWriter) flush() error {
+	cw.w.Flush()
+	if err := cw.w.Error(); err != nil {
+		return err
+	}
+	cw.out.Flush()
+	return nil
+}
+
+// xlsxExportWriter Excel 格式，使用 excelize 的流式写入，数据量大时会暂存到临时文件
+// xlsx 是 zip 格式，只能在全部写完后一次性输出
+type xlsxExportWriter struct {
+	out  io.Writer
+	f    *excelize.File
+	sw   *excelize.StreamWriter
+	rows int
+	err  error
+}
+
+func newXLSXExportWriter(out io.Writer) *xlsxExportWriter {
+	f := excelize.NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	return &xlsxExportWriter{out: out, f: f, sw: sw, err: err}
+}
+
+func (xw *xlsxExportWriter) Write(row []any) error {
+	if xw.err != nil {
+		return xw.err
+	}
+	xw.rows++
+	cell, err := excelize.CoordinatesToCellName(1, xw.rows)
+	if err != nil {
+		return err
+	}
+	return xw.sw.SetRow(cell, row)
+}
+
+func (xw *xlsxExportWriter) Close() error {
+	defer xw.f.Close()
+	if xw.err != nil {
+		return xw.err
+	}
+	if err := xw.sw.Flush(); err != nil {
+		return err
+	}
+	_, err := xw.f.WriteTo(xw.out)
+	return err
+}
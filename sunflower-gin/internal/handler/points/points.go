@@ -1,6 +1,9 @@
 package points
 
 import (
+	"errors"
+	"time"
+
 	"sunflower-gin/api"
 	v1 "sunflower-gin/api/points/v1"
 	"sunflower-gin/internal/middleware"
@@ -27,7 +30,7 @@ func SummaryHandler(c *gin.Context) {
 	// 2. 调用 service 层获取积分信息
 	output, err := points.Summary(c, userID)
 	if err != nil {
-		api.ResponseErrorWithMsg(c, api.CodeServerBusy, err.Error())
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
 		return
 	}
 	// 3. 返回积分信息
@@ -40,7 +43,7 @@ func RecordsHandler(c *gin.Context) {
 	// c.GetQuery("limit")
 	var req v1.RecordsReq
 	if err := c.ShouldBind(&req); err != nil {
-		api.ResponseError(c, api.CodeInvalidParam)
+		api.ResponseInvalidParam(c, err)
 		return
 	}
 	userID := c.Value(middleware.CtxKeyUserID).(int64)
@@ -55,14 +58,35 @@ func RecordsHandler(c *gin.Context) {
 	if req.Offset < 0 {
 		req.Offset = defaultOffset
 	}
+	input := &model.RecordsInput{
+		UserID:    userID,
+		Cursor:    req.Cursor,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+		Types:     req.Type,
+		Sign:      req.Sign,
+		WithTotal: req.WithTotal,
+	}
+	// 日期范围转换为 [StartTime, EndTime) 的时间范围，格式已经由 binding 校验过
+	if req.StartDate != "" {
+		input.StartTime, _ = time.ParseInLocation(time.DateOnly, req.StartDate, time.Local)
+	}
+	if req.EndDate != "" {
+		endDate, _ := time.ParseInLocation(time.DateOnly, req.EndDate, time.Local)
+		input.EndTime = endDate.AddDate(0, 0, 1)
+	}
+	if !input.StartTime.IsZero() && !input.EndTime.IsZero() && !input.StartTime.Before(input.EndTime) {
+		api.ResponseError(c, api.CodeInvalidParam)
+		return
+	}
 	// 2. 调用 service 层获取积分记录
-	output, err := points.Records(c, &model.RecordsInput{
-		UserID: userID,
-		Limit:  req.Limit,
-		Offset: req.Offset,
-	})
+	output, err := points.Records(c, input)
 	if err != nil {
-		api.ResponseErrorWithMsg(c, api.CodeServerBusy, err.Error())
+		if errors.Is(err, points.ErrInvalidCursor) {
+			api.ResponseErrorWithErr(c, api.CodeInvalidParam, err)
+			return
+		}
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
 		return
 	}
 	// 3. 返回积分记录
@@ -77,8 +101,75 @@ func RecordsHandler(c *gin.Context) {
 		})
 	}
 	api.ResponseSuccess(c, &v1.RecordsResp{
-		Total:   output.Total,
-		HasMore: output.HasMore,
-		List:    list,
+		Total:      output.Total,
+		HasMore:    output.HasMore,
+		NextCursor: output.NextCursor,
+		List:       list,
 	})
 }
+
+// StatsHandler 获取积分统计
+func StatsHandler(c *gin.Context) {
+	// 1. 获取当前用户信息和统计参数
+	var req v1.StatsReq
+	if err := c.ShouldBind(&req); err != nil {
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	input := &model.StatsInput{
+		UserID:      userID,
+		Granularity: req.Granularity,
+	}
+	// 日期范围转换为 [StartTime, EndTime) 的时间范围，格式已经由 binding 校验过
+	if req.StartDate != "" {
+		input.StartTime, _ = time.ParseInLocation(time.DateOnly, req.StartDate, time.Local)
+	}
+	if req.EndDate != "" {
+		endDate, _ := time.ParseInLocation(time.DateOnly, req.EndDate, time.Local)
+		input.EndTime = endDate.AddDate(0, 0, 1)
+	}
+	if !input.StartTime.IsZero() && !input.EndTime.IsZero() && !input.StartTime.Before(input.EndTime) {
+		api.ResponseError(c, api.CodeInvalidParam)
+		return
+	}
+	// 2. 调用 service 层获取积分统计
+	output, err := points.Stats(c, input)
+	if err != nil {
+		if errors.Is(err, points.ErrStatsRangeTooLarge) {
+			api.ResponseErrorWithErr(c, api.CodeInvalidParam, err)
+			return
+		}
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
+		return
+	}
+	// 3. 返回积分统计
+	resp := &v1.StatsResp{
+		Balance:        output.Balance,
+		LifetimeEarned: output.LifetimeEarned,
+		LifetimeSpent:  output.LifetimeSpent,
+		ByType:         make([]*v1.TypeStats, 0, len(output.ByType)),
+		Granularity:    output.Granularity,
+		Series:         make([]*v1.SeriesPoint, 0, len(output.Series)),
+	}
+	for _, v := range output.ByType {
+		resp.ByType = append(resp.ByType, &v1.TypeStats{
+			TransactionType: v.TransactionType,
+			Earned:          v.Earned,
+			Spent:           v.Spent,
+			Count:           v.Count,
+		})
+	}
+	for _, v := range output.Series {
+		resp.Series = append(resp.Series, &v1.SeriesPoint{
+			Period: v.Period,
+			Earned: v.Earned,
+			Spent:  v.Spent,
+		})
+	}
+	api.ResponseSuccess(c, resp)
+}
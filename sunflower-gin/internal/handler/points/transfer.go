@@ -0,0 +1,151 @@
+package points
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"sunflower-gin/api"
+	v1 "sunflower-gin/api/points/v1"
+	"sunflower-gin/internal/middleware"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/transfer"
+	"sunflower-gin/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TransferHandler 发起转赠，大额转赠返回待确认状态，需要再调用确认接口
+func TransferHandler(c *gin.Context) {
+	// 1. 获取请求参数和当前用户
+	var req v1.TransferReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	// 2. 调用 service 层发起转赠
+	output, err := transfer.Create(c, &model.CreateTransferInput{
+		FromUserID: userID,
+		ToUsername: req.ToUsername,
+		Amount:     req.Amount,
+		Remark:     req.Remark,
+	})
+	if err != nil {
+		api.ResponseErrorWithErr(c, transferErrCode(err), err)
+		return
+	}
+	// 3. 返回转赠信息
+	api.ResponseSuccess(c, toTransferInfo(output))
+}
+
+// ConfirmTransferHandler 输入登录密码确认大额转赠
+func ConfirmTransferHandler(c *gin.Context) {
+	// 1. 获取请求参数和当前用户
+	var req v1.ConfirmTransferReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	transferNo, err := strconv.ParseInt(c.Param("transferNo"), 10, 64)
+	if err != nil {
+		api.ResponseError(c, api.CodeInvalidParam)
+		return
+	}
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	// 2. 调用 service 层确认转赠
+	output, err := transfer.Confirm(c, &model.ConfirmTransferInput{
+		UserID:     userID,
+		TransferNo: transferNo,
+		Password:   req.Password,
+	})
+	if err != nil {
+		api.ResponseErrorWithErr(c, transferErrCode(err), err)
+		return
+	}
+	// 3. 返回转赠信息
+	api.ResponseSuccess(c, toTransferInfo(output))
+}
+
+// TransferListHandler 获取转入和转出的转赠记录
+func TransferListHandler(c *gin.Context) {
+	// 1. 获取当前用户信息和分页信息
+	var req v1.TransferListReq
+	if err := c.ShouldBind(&req); err != nil {
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	if req.Limit <= 0 || req.Limit > maxLimit {
+		req.Limit = defaultLimit
+	}
+	// 2. 调用 service 层获取转赠记录
+	output, err := transfer.List(c, &model.TransferListInput{
+		UserID:    userID,
+		Direction: req.Direction,
+		Cursor:    req.Cursor,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		api.ResponseErrorWithErr(c, transferErrCode(err), err)
+		return
+	}
+	// 3. 返回转赠记录
+	list := make([]*v1.TransferInfo, 0, len(output.List))
+	for _, item := range output.List {
+		list = append(list, toTransferInfo(item))
+	}
+	api.ResponseSuccess(c, &v1.TransferListResp{
+		HasMore:    output.HasMore,
+		NextCursor: output.NextCursor,
+		List:       list,
+	})
+}
+
+// transferErrCode 转赠相关错误对应的业务错误码
+func transferErrCode(err error) api.ResCode {
+	switch {
+	case errors.Is(err, transfer.ErrRecipientNotExist):
+		return api.CodeUserNotExist
+	case errors.Is(err, transfer.ErrInvalidPassword):
+		return api.CodeInvalidPassword
+	}
+	// 其它可翻译的错误都是业务校验不通过
+	var e *i18n.Error
+	if errors.As(err, &e) {
+		return api.CodeInvalidParam
+	}
+	return api.CodeServerBusy
+}
+
+func toTransferInfo(info *model.TransferInfo) *v1.TransferInfo {
+	resp := &v1.TransferInfo{
+		TransferNo:   info.TransferNo,
+		Direction:    info.Direction,
+		Counterparty: info.Counterparty,
+		Amount:       info.Amount,
+		Status:       info.Status,
+		NeedConfirm:  info.Status == int32(model.PointsTransferStatusPending),
+		Remark:       info.Remark,
+		CreatedTime:  info.CreatedAt.Format(time.DateTime),
+	}
+	if info.CompletedAt != nil {
+		resp.CompleteTime = info.CompletedAt.Format(time.DateTime)
+	}
+	if info.ExpireAt != nil && info.Status == int32(model.PointsTransferStatusPending) {
+		resp.ExpireTime = info.ExpireAt.Format(time.DateTime)
+	}
+	return resp
+}
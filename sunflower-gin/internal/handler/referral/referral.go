@@ -0,0 +1,93 @@
+package referral
+
+import (
+	"time"
+
+	"sunflower-gin/api"
+	v1 "sunflower-gin/api/referral/v1"
+	"sunflower-gin/internal/middleware"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/referral"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultLimit = 20  // 默认分页大小
+	maxLimit     = 100 // 最大分页大小
+)
+
+// DashboardHandler 当前用户的邀请码、奖励规则和邀请统计
+func DashboardHandler(c *gin.Context) {
+	// 1. 获取当前用户
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	// 2. 调用 service 层查询邀请信息
+	output, err := referral.Dashboard(c, userID)
+	if err != nil {
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
+		return
+	}
+	// 3. 返回邀请信息
+	api.ResponseSuccess(c, &v1.DashboardResp{
+		Code:             output.Code,
+		RequiredCheckins: output.RequiredCheckins,
+		InviterPoints:    output.InviterPoints,
+		InviteePoints:    output.InviteePoints,
+		Invited:          output.Invited,
+		Pending:          output.Pending,
+		Rewarded:         output.Rewarded,
+		Rejected:         output.Rejected,
+		EarnedPoints:     output.EarnedPoints,
+	})
+}
+
+// InviteeListHandler 当前用户邀请的用户和签到进度
+func InviteeListHandler(c *gin.Context) {
+	// 1. 获取分页信息和当前用户
+	var req v1.InviteeListReq
+	if err := c.ShouldBind(&req); err != nil {
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	if req.Limit <= 0 || req.Limit > maxLimit {
+		req.Limit = defaultLimit
+	}
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	// 2. 调用 service 层查询邀请记录
+	output, err := referral.Invitees(c, &model.ReferralInviteeListInput{
+		UserID: userID,
+		Offset: max(req.Offset, 0),
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
+		return
+	}
+	// 3. 返回邀请记录
+	list := make([]*v1.InviteeInfo, 0, len(output.List))
+	for _, item := range output.List {
+		info := &v1.InviteeInfo{
+			Username:       item.Username,
+			Status:         int32(item.Status),
+			Checkins:       item.Checkins,
+			EarnedPoints:   item.InviterPoints,
+			RegisteredTime: item.RegisteredAt.Format(time.DateTime),
+		}
+		if item.RewardedAt != nil {
+			info.RewardedTime = item.RewardedAt.Format(time.DateTime)
+		}
+		list = append(list, info)
+	}
+	api.ResponseSuccess(c, &v1.InviteeListResp{
+		Total: output.Total,
+		List:  list,
+	})
+}
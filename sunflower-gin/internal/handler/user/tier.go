@@ -0,0 +1,63 @@
+package user
+
+import (
+	"time"
+
+	"sunflower-gin/api"
+	v1 "sunflower-gin/api/user/v1"
+	"sunflower-gin/internal/middleware"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/tier"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultTierHistoryLimit = 20  // 默认分页大小
+	maxTierHistoryLimit     = 100 // 最大分页大小
+)
+
+// TierHistoryHandler 当前用户的等级变化记录
+func TierHistoryHandler(c *gin.Context) {
+	// 1. 获取分页信息和当前用户
+	var req v1.TierHistoryReq
+	if err := c.ShouldBind(&req); err != nil {
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	if req.Limit <= 0 || req.Limit > maxTierHistoryLimit {
+		req.Limit = defaultTierHistoryLimit
+	}
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	// 2. 调用 service 层查询等级变化记录
+	output, err := tier.History(c, &model.TierHistoryListInput{
+		UserID: userID,
+		Offset: max(req.Offset, 0),
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		api.ResponseErrorWithErr(c, api.CodeServerBusy, err)
+		return
+	}
+	// 3. 返回等级变化记录
+	list := make([]*v1.TierHistoryInfo, 0, len(output.List))
+	for _, item := range output.List {
+		list = append(list, &v1.TierHistoryInfo{
+			FromLevel:      item.FromLevel,
+			FromName:       item.FromName,
+			ToLevel:        item.ToLevel,
+			ToName:         item.ToName,
+			LifetimePoints: item.LifetimePoints,
+			BestStreak:     item.BestStreak,
+			CreatedTime:    item.CreatedAt.Format(time.DateTime),
+		})
+	}
+	api.ResponseSuccess(c, &v1.TierHistoryResp{
+		Total: output.Total,
+		List:  list,
+	})
+}
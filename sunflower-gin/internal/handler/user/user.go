@@ -6,7 +6,10 @@ import (
 	v1 "sunflower-gin/api/user/v1"
 	"sunflower-gin/internal/middleware"
 	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/referral"
+	"sunflower-gin/internal/service/tier"
 	"sunflower-gin/internal/service/user"
+	"sunflower-gin/pkg/logging"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -14,22 +17,29 @@ import (
 
 // hanlder 层
 
+const (
+	HeaderDeviceID = "X-Device-ID" // 客户端设备ID，注册时用于邀请的防刷
+)
+
 // CreateHandler 创建用户接口
 func CreateHandler(c *gin.Context) {
 	// 1. 获取请求参数&校验参数
 	var req v1.CreateReq
 	if err := c.ShouldBindJSON(&req); err != nil {
 		// 请求参数有问题
-		zap.L().Error("CreateHandler: ShouldBindJSON failed", zap.Error(err))
-		api.ResponseError(c, api.CodeInvalidParam)
+		logging.Ctx(c).Error("CreateHandler: ShouldBindJSON failed", zap.Error(err))
+		api.ResponseInvalidParam(c, err)
 		return
 	}
-	zap.L().Sugar().Debugf("---> CreateHandler: %+v", req)
+	logging.Ctx(c).Sugar().Debugf("---> CreateHandler: %+v", req)
 	// 2. 执行业务逻辑
 	input := &model.CreateUserInput{
-		Username: req.Username,
-		Password: req.Password,
-		Email:    req.Email,
+		Username:   req.Username,
+		Password:   req.Password,
+		Email:      req.Email,
+		InviteCode: req.InviteCode,
+		ClientIP:   c.ClientIP(),
+		DeviceID:   c.GetHeader(HeaderDeviceID),
 	}
 	output, err := user.Create(c, input)
 	if err != nil {
@@ -38,6 +48,11 @@ func CreateHandler(c *gin.Context) {
 			api.ResponseError(c, api.CodeUserExist)
 			return
 		}
+		// 邀请码不存在
+		if errors.Is(err, referral.ErrInvalidCode) {
+			api.ResponseErrorWithErr(c, api.CodeInvalidParam, err)
+			return
+		}
 		// 其它错误，统一返回服务繁忙
 		api.ResponseError(c, api.CodeServerBusy)
 		return
@@ -64,9 +79,67 @@ func ProfileHandler(c *gin.Context) {
 		return
 	}
 	// 3. 返回响应
-	api.ResponseSuccess(c, &v1.MeRes{
+	api.ResponseSuccess(c, toMeRes(c, userID, output))
+}
+
+// UpdateProfileHandler 修改当前用户信息接口
+func UpdateProfileHandler(c *gin.Context) {
+	// 1. 获取请求参数&校验参数
+	userID := c.Value(middleware.CtxKeyUserID).(int64)
+	if userID == 0 {
+		api.ResponseError(c, api.CodeNeedLogin)
+		return
+	}
+	var req v1.UpdateMeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logging.Ctx(c).Error("UpdateProfileHandler: ShouldBindJSON failed", zap.Error(err))
+		api.ResponseInvalidParam(c, err)
+		return
+	}
+	// 2. 执行业务逻辑
+	err := user.UpdateProfile(c, &model.UpdateProfileInput{
+		UserID: userID,
+		Email:  req.Email,
+		Avatar: req.Avatar,
+	})
+	if err != nil {
+		api.ResponseError(c, api.CodeServerBusy)
+		return
+	}
+	output, err := user.GetProfile(c, userID)
+	if err != nil {
+		api.ResponseError(c, api.CodeServerBusy)
+		return
+	}
+	// 3. 返回修改后的用户信息
+	api.ResponseSuccess(c, toMeRes(c, userID, output))
+}
+
+// toMeRes 组装当前用户信息，等级查询失败时不返回等级，不影响用户信息
+func toMeRes(c *gin.Context, userID int64, output *model.UserProfileOutput) *v1.MeRes {
+	res := &v1.MeRes{
 		Username: output.Username,
 		Email:    output.Email,
 		Avatar:   output.Avatar,
-	})
+	}
+	info, err := tier.Get(c, userID)
+	if err != nil {
+		logging.Ctx(c).Error("tier.Get failed", zap.Error(err))
+		return res
+	}
+	res.Tier = &v1.TierInfo{
+		Level:          info.Level,
+		Name:           info.Name,
+		LifetimePoints: info.LifetimePoints,
+		BestStreak:     info.BestStreak,
+	}
+	if info.Next != nil {
+		res.Tier.Next = &v1.TierRequirement{
+			Level:             info.Next.Level,
+			Name:              info.Next.Name,
+			MinLifetimePoints: info.Next.MinLifetimePoints,
+			MinStreakDays:     info.Next.MinStreakDays,
+		}
+	}
+	return res
 }
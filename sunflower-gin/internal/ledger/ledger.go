@@ -0,0 +1,413 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 复式记账
+// 每一笔积分变动记一条分录，分录下每个账户一行，所有行的金额之和为 0，积分不会凭空产生或消失：
+// 签到奖励从系统发放账户转入用户钱包，补签消耗从用户钱包转入销毁账户，转赠在两个用户钱包之间转移
+// 系统账户（发放、销毁）参与所有用户的积分变动，不维护余额，只写记账明细，需要时按 ledger_postings 汇总，
+// 否则所有发放积分的事务都要排队更新同一行
+// 用户钱包记账时同步更新 user_points 的余额并写入 user_points_transactions 流水，原有的积分查询接口不受影响
+
+const (
+	openingDescKey = "points.desc.opening" // 期初余额
+)
+
+var (
+	ErrInsufficientBalance = i18n.NewError("error.ledger.insufficient_balance") // 账户余额不足
+)
+
+// Post 记一笔分录，必须在调用方的事务中执行，事务回滚时分录一起回滚
+// 用户钱包和活动奖池按账户ID的顺序加锁后校验余额，系统账户不加锁也不更新余额
+func Post(ctx context.Context, tx *query.Query, input *model.LedgerEntryInput) (*model.LedgerEntryOutput, error) {
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+	// 1. 找到每一行对应的账户，不存在时创建
+	accounts := make([]*model.LedgerAccount, len(input.Lines))
+	var lockIDs []int64
+	for i, line := range input.Lines {
+		account, err := ensureAccount(ctx, tx, line.AccountType, line.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+		accounts[i] = account
+		if !isSystem(line.AccountType) {
+			lockIDs = append(lockIDs, account.ID)
+		}
+	}
+	// 2. 需要校验余额的账户按ID顺序加锁，多个事务同时操作相同的账户时不会死锁
+	locked := make(map[int64]*model.LedgerAccount, len(lockIDs))
+	if len(lockIDs) > 0 {
+		a := tx.LedgerAccount
+		list, err := a.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(a.ID.In(lockIDs...)).
+			Order(a.ID).
+			Find()
+		if err != nil {
+			logging.Ctx(ctx).Error("lock ledger_accounts error", zap.Error(err))
+			return nil, err
+		}
+		for _, v := range list {
+			locked[v.ID] = v
+		}
+	}
+	// 3. 计算加锁账户记账后的余额
+	for i, line := range input.Lines {
+		account, ok := locked[accounts[i].ID]
+		if !ok {
+			continue
+		}
+		balance := account.Balance + line.Amount
+		if balance < 0 && !line.AccountType.AllowNegative() {
+			return nil, ErrInsufficientBalance
+		}
+		account.Balance = balance
+	}
+	// 4. 写入分录
+	ext := input.Ext
+	ext.DescKey, ext.DescArgs = input.DescKey, input.DescArgs
+	extJSON, err := ext.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	entry := &model.LedgerEntry{
+		TransactionType: int32(input.Type),
+		Description:     i18n.T(i18n.DefaultLang, input.DescKey, input.DescArgs...),
+		ExtJSON:         extJSON,
+	}
+	if err := tx.LedgerEntry.WithContext(ctx).Create(entry); err != nil {
+		logging.Ctx(ctx).Error("create ledger_entries error", zap.Error(err))
+		return nil, err
+	}
+	// 5. 更新账户余额，用户钱包同步更新积分余额和流水，系统账户只写记账明细，记账后余额记为 0
+	output := &model.LedgerEntryOutput{
+		EntryID:      entry.ID,
+		Transactions: make([]*model.UserPointsTransaction, len(input.Lines)),
+	}
+	postings := make([]*model.LedgerPosting, len(input.Lines))
+	for i, line := range input.Lines {
+		account, ok := locked[accounts[i].ID]
+		if !ok {
+			continue
+		}
+		if err := setBalance(ctx, tx, account); err != nil {
+			return nil, err
+		}
+		if line.AccountType == model.LedgerAccountTypeUserWallet {
+			record, err := syncUserPoints(ctx, tx, entry, input, line, account.Balance)
+			if err != nil {
+				return nil, err
+			}
+			output.Transactions[i] = record
+		}
+		postings[i] = &model.LedgerPosting{EntryID: entry.ID, AccountID: account.ID, Amount: line.Amount, BalanceAfter: account.Balance}
+	}
+	for i, line := range input.Lines {
+		if postings[i] == nil {
+			postings[i] = &model.LedgerPosting{EntryID: entry.ID, AccountID: accounts[i].ID, Amount: line.Amount}
+		}
+	}
+	// 6. 写入记账明细
+	if err := tx.LedgerPosting.WithContext(ctx).Create(postings...); err != nil {
+		logging.Ctx(ctx).Error("create ledger_postings error", zap.Error(err))
+		return nil, err
+	}
+	return output, nil
+}
+
+// validate 校验分录是否平衡，不平衡属于调用方的编码错误，不需要翻译
+func validate(input *model.LedgerEntryInput) error {
+	if len(input.Lines) < 2 {
+		return fmt.Errorf("ledger: entry needs at least 2 lines, got %d", len(input.Lines))
+	}
+	type accountKey struct {
+		accountType model.LedgerAccountType
+		ownerID     int64
+	}
+	seen := make(map[accountKey]bool, len(input.Lines))
+	var sum int64
+	for _, line := range input.Lines {
+		if line.Amount == 0 {
+			return fmt.Errorf("ledger: zero amount on %s:%d", line.AccountType, line.OwnerID)
+		}
+		key := accountKey{line.AccountType, line.OwnerID}
+		if seen[key] {
+			return fmt.Errorf("ledger: duplicate account %s:%d", line.AccountType, line.OwnerID)
+		}
+		seen[key] = true
+		sum += line.Amount
+	}
+	if sum != 0 {
+		return fmt.Errorf("ledger: unbalanced entry, sum of lines is %d", sum)
+	}
+	return nil
+}
+
+// isEarning 分录是否为用户获得新积分：由系统发放账户或活动奖池出资，对账校正除外
+// 用户之间的转赠只是转移已有的积分，不计入累计获得，否则来回转赠就能刷高累计积分，解锁等级和成就
+func isEarning(input *model.LedgerEntryInput) bool {
+	if input.Type == model.PointsTransactionTypeCorrection {
+		return false
+	}
+	for _, line := range input.Lines {
+		if line.Amount < 0 && (line.AccountType == model.LedgerAccountTypeIssuance || line.AccountType == model.LedgerAccountTypeCampaignPool) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSystem 系统账户不校验余额，不维护余额，也不需要在记账前加锁
+func isSystem(t model.LedgerAccountType) bool {
+	return slices.Contains([]model.LedgerAccountType{model.LedgerAccountTypeIssuance, model.LedgerAccountTypeBurn}, t)
+}
+
+// ensureAccount 查询账户，不存在时创建
+func ensureAccount(ctx context.Context, tx *query.Query, accountType model.LedgerAccountType, ownerID int64) (*model.LedgerAccount, error) {
+	a := tx.LedgerAccount
+	account, err := a.WithContext(ctx).
+		Where(a.Type.Eq(int32(accountType)), a.OwnerID.Eq(ownerID)).
+		First()
+	if err == nil {
+		return account, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logging.Ctx(ctx).Error("query ledger_accounts error", zap.Error(err))
+		return nil, err
+	}
+	if accountType == model.LedgerAccountTypeUserWallet {
+		return openWallet(ctx, tx, ownerID)
+	}
+	account, _, err = createAccount(ctx, tx, accountType, ownerID)
+	return account, err
+}
+
+// createAccount 创建账户，并发创建同一个账户时只有一个事务会成功，created 表示是否由当前事务创建
+func createAccount(ctx context.Context, tx *query.Query, accountType model.LedgerAccountType, ownerID int64) (account *model.LedgerAccount, created bool, err error) {
+	account = &model.LedgerAccount{Type: int32(accountType), OwnerID: ownerID}
+	a := tx.LedgerAccount
+	res := a.WithContext(ctx).UnderlyingDB().Clauses(clause.OnConflict{DoNothing: true}).Create(account)
+	if res.Error != nil {
+		logging.Ctx(ctx).Error("create ledger_accounts error", zap.Error(res.Error))
+		return nil, false, res.Error
+	}
+	if res.RowsAffected > 0 {
+		return account, true, nil
+	}
+	// 已经被其它事务创建了，一致性读可能看不到，用加锁读取最新的数据
+	account, err = a.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "SHARE"}).
+		Where(a.Type.Eq(int32(accountType)), a.OwnerID.Eq(ownerID)).
+		First()
+	if err != nil {
+		logging.Ctx(ctx).Error("query ledger_accounts error", zap.Error(err))
+		return nil, false, err
+	}
+	return account, false, nil
+}
+
+// openWallet 创建用户钱包，用户已经有积分时按 user_points 的余额记一笔期初分录
+// 期初分录不生成用户积分流水，这部分积分的流水已经在 user_points_transactions 中
+func openWallet(ctx context.Context, tx *query.Query, userID int64) (*model.LedgerAccount, error) {
+	account, created, err := createAccount(ctx, tx, model.LedgerAccountTypeUserWallet, userID)
+	if err != nil || !created {
+		return account, err
+	}
+	// 1. 钱包由当前事务创建，读取 user_points 的余额，还没有积分记录时创建
+	up, err := tx.UserPoint.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(tx.UserPoint.UserID.Eq(userID)).
+		First()
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logging.Ctx(ctx).Error("query user_points error", zap.Error(err))
+			return nil, err
+		}
+		up = &model.UserPoint{UserID: userID}
+		if err := tx.UserPoint.WithContext(ctx).Create(up); err != nil {
+			logging.Ctx(ctx).Error("create user_points error", zap.Error(err))
+			return nil, err
+		}
+	}
+	if up.Points == 0 {
+		return account, nil
+	}
+	// 2. 期初余额从系统发放账户转入
+	issuance, err := ensureAccount(ctx, tx, model.LedgerAccountTypeIssuance, 0)
+	if err != nil {
+		return nil, err
+	}
+	extJSON, err := model.TransactionExt{DescKey: openingDescKey}.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	entry := &model.LedgerEntry{
+		TransactionType: int32(model.PointsTransactionTypeOpening),
+		Description:     i18n.T(i18n.DefaultLang, openingDescKey),
+		ExtJSON:         extJSON,
+	}
+	if err := tx.LedgerEntry.WithContext(ctx).Create(entry); err != nil {
+		logging.Ctx(ctx).Error("create ledger_entries error", zap.Error(err))
+		return nil, err
+	}
+	account.Balance = up.Points
+	if err := setBalance(ctx, tx, account); err != nil {
+		return nil, err
+	}
+	if err := tx.LedgerPosting.WithContext(ctx).Create(
+		&model.LedgerPosting{EntryID: entry.ID, AccountID: issuance.ID, Amount: -up.Points},
+		&model.LedgerPosting{EntryID: entry.ID, AccountID: account.ID, Amount: up.Points, BalanceAfter: account.Balance},
+	); err != nil {
+		logging.Ctx(ctx).Error("create ledger_postings error", zap.Error(err))
+		return nil, err
+	}
+	return account, nil
+}
+
+// setBalance 更新已加锁账户的余额
+func setBalance(ctx context.Context, tx *query.Query, account *model.LedgerAccount) error {
+	a := tx.LedgerAccount
+	if _, err := a.WithContext(ctx).
+		Where(a.ID.Eq(account.ID)).
+		UpdateSimple(a.Balance.Value(account.Balance)); err != nil {
+		logging.Ctx(ctx).Error("update ledger_accounts error", zap.Int64("account_id", account.ID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// syncUserPoints 用户钱包记账后同步更新 user_points 的余额，并写入一条用户积分流水
+func syncUserPoints(ctx context.Context, tx *query.Query, entry *model.LedgerEntry, input *model.LedgerEntryInput,
+	line *model.LedgerLine, balance int64) (*model.UserPointsTransaction, error) {
+	// 1. 更新积分余额，只有系统发放或活动奖池转入的积分计入累计获得
+	up := tx.UserPoint
+	var earned int64
+	if line.Amount > 0 && isEarning(input) {
+		earned = line.Amount
+	}
+	if _, err := up.WithContext(ctx).
+		Where(up.UserID.Eq(line.OwnerID)).
+		UpdateSimple(up.Points.Value(balance), up.PointsTotal.Add(earned)); err != nil {
+		logging.Ctx(ctx).Error("update user_points error", zap.Int64("user_id", line.OwnerID), zap.Error(err))
+		return nil, err
+	}
+	// 2. 写入积分流水，描述信息按默认语言入库，同时把多语言 key 记录在 ExtJSON 中，展示时按读者语言翻译
+	transactionType, descKey, descArgs := input.Type, input.DescKey, input.DescArgs
+	if line.TransactionType != 0 {
+		transactionType = line.TransactionType
+	}
+	if line.DescKey != "" {
+		descKey, descArgs = line.DescKey, line.DescArgs
+	}
+	ext := input.Ext
+	ext.DescKey, ext.DescArgs, ext.EntryID = descKey, descArgs, entry.ID
+	extJSON, err := ext.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	record := &model.UserPointsTransaction{
+		UserID:          line.OwnerID,
+		PointsChange:    line.Amount,
+		CurrentBalance:  balance,
+		TransactionType: int32(transactionType),
+		Description:     i18n.T(i18n.DefaultLang, descKey, descArgs...),
+		ExtJSON:         extJSON,
+	}
+	if err := tx.UserPointsTransaction.WithContext(ctx).Create(record); err != nil {
+		logging.Ctx(ctx).Error("create user_points_transactions error", zap.Int64("user_id", line.OwnerID), zap.Error(err))
+		return nil, err
+	}
+	return record, nil
+}
+
+// Balance 在事务中加锁读取账户余额，账户不存在时返回 0
+func Balance(ctx context.Context, tx *query.Query, accountType model.LedgerAccountType, ownerID int64) (int64, error) {
+	a := tx.LedgerAccount
+	account, err := a.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(a.Type.Eq(int32(accountType)), a.OwnerID.Eq(ownerID)).
+		First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		logging.Ctx(ctx).Error("query ledger_accounts error", zap.Error(err))
+		return 0, err
+	}
+	return account.Balance, nil
+}
+
+// Balances 批量查询同一类账户的余额，用于展示，key 为 OwnerID，账户不存在时没有对应的 key
+func Balances(ctx context.Context, accountType model.LedgerAccountType, ownerIDs ...int64) (map[int64]int64, error) {
+	balances := make(map[int64]int64, len(ownerIDs))
+	if len(ownerIDs) == 0 {
+		return balances, nil
+	}
+	a := query.LedgerAccount
+	list, err := a.WithContext(ctx).
+		Where(a.Type.Eq(int32(accountType)), a.OwnerID.In(ownerIDs...)).
+		Find()
+	if err != nil {
+		logging.Ctx(ctx).Error("query ledger_accounts error", zap.Error(err))
+		return nil, err
+	}
+	for _, v := range list {
+		balances[v.OwnerID] = v.Balance
+	}
+	return balances, nil
+}
+
+// Wallet 查询用户钱包，lock 为 true 时加锁读取，钱包还没有创建时返回 nil
+func Wallet(ctx context.Context, tx *query.Query, userID int64, lock bool) (*model.LedgerAccount, error) {
+	a := tx.LedgerAccount
+	do := a.WithContext(ctx).WriteDB()
+	if lock {
+		do = do.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	account, err := do.Where(a.Type.Eq(int32(model.LedgerAccountTypeUserWallet)), a.OwnerID.Eq(userID)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logging.Ctx(ctx).Error("query ledger_accounts error", zap.Int64("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	return account, nil
+}
+
+// PostingsSum 汇总账户所有记账明细的金额，即按记账明细计算出的账户余额
+func PostingsSum(ctx context.Context, tx *query.Query, accountID int64) (int64, error) {
+	p := tx.LedgerPosting
+	var sum int64
+	if err := p.WithContext(ctx).WriteDB().
+		Where(p.AccountID.Eq(accountID)).
+		UnderlyingDB().
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&sum).Error; err != nil {
+		logging.Ctx(ctx).Error("sum ledger_postings error", zap.Int64("account_id", accountID), zap.Error(err))
+		return 0, err
+	}
+	return sum, nil
+}
+
+// ResetBalance 把已加锁账户的余额改为 balance，只用于对账修复账户余额与记账明细不一致
+func ResetBalance(ctx context.Context, tx *query.Query, account *model.LedgerAccount, balance int64) error {
+	account.Balance = balance
+	return setBalance(ctx, tx, account)
+}
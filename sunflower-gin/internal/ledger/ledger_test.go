@@ -0,0 +1,56 @@
+package ledger
+
+import (
+	"testing"
+
+	"sunflower-gin/internal/model"
+)
+
+func TestValidate(t *testing.T) {
+	const (
+		wallet   = model.LedgerAccountTypeUserWallet
+		issuance = model.LedgerAccountTypeIssuance
+		burn     = model.LedgerAccountTypeBurn
+	)
+	tests := []struct {
+		name    string
+		lines   []*model.LedgerLine
+		wantErr bool
+	}{
+		{"issue to wallet", []*model.LedgerLine{
+			{AccountType: issuance, Amount: -10},
+			{AccountType: wallet, OwnerID: 1, Amount: 10},
+		}, false},
+		{"transfer between wallets", []*model.LedgerLine{
+			{AccountType: wallet, OwnerID: 1, Amount: -5},
+			{AccountType: wallet, OwnerID: 2, Amount: 5},
+		}, false},
+		{"one entry for two wallets", []*model.LedgerLine{
+			{AccountType: issuance, Amount: -70},
+			{AccountType: wallet, OwnerID: 1, Amount: 50},
+			{AccountType: wallet, OwnerID: 2, Amount: 20},
+		}, false},
+		{"single line", []*model.LedgerLine{
+			{AccountType: wallet, OwnerID: 1, Amount: 10},
+		}, true},
+		{"unbalanced", []*model.LedgerLine{
+			{AccountType: wallet, OwnerID: 1, Amount: -5},
+			{AccountType: burn, Amount: 4},
+		}, true},
+		{"zero amount", []*model.LedgerLine{
+			{AccountType: issuance, Amount: -10},
+			{AccountType: wallet, OwnerID: 1, Amount: 10},
+			{AccountType: wallet, OwnerID: 2, Amount: 0},
+		}, true},
+		{"duplicate account", []*model.LedgerLine{
+			{AccountType: wallet, OwnerID: 1, Amount: -5},
+			{AccountType: wallet, OwnerID: 1, Amount: 5},
+		}, true},
+	}
+	for _, tt := range tests {
+		err := validate(&model.LedgerEntryInput{Type: model.PointsTransactionTypeDaily, Lines: tt.lines})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GORM 插件，通过 callback 统计每条 SQL 的执行耗时
+
+const (
+	gormStartTimeKey = "metrics:start_time"
+	gormCallbackName = "metrics"
+)
+
+// GormPlugin 统计 SQL 执行耗时的 GORM 插件
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string {
+	return "metrics"
+}
+
+// Initialize 在每类操作的前后注册 callback
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register(gormCallbackName+":before_create", before),
+		cb.Create().After("gorm:create").Register(gormCallbackName+":after_create", after("create")),
+		cb.Query().Before("gorm:query").Register(gormCallbackName+":before_query", before),
+		cb.Query().After("gorm:query").Register(gormCallbackName+":after_query", after("query")),
+		cb.Update().Before("gorm:update").Register(gormCallbackName+":before_update", before),
+		cb.Update().After("gorm:update").Register(gormCallbackName+":after_update", after("update")),
+		cb.Delete().Before("gorm:delete").Register(gormCallbackName+":before_delete", before),
+		cb.Delete().After("gorm:delete").Register(gormCallbackName+":after_delete", after("delete")),
+		cb.Row().Before("gorm:row").Register(gormCallbackName+":before_row", before),
+		cb.Row().After("gorm:row").Register(gormCallbackName+":after_row", after("row")),
+		cb.Raw().Before("gorm:raw").Register(gormCallbackName+":before_raw", before),
+		cb.Raw().After("gorm:raw").Register(gormCallbackName+":after_raw", after("raw")),
+	)
+}
+
+func before(db *gorm.DB) {
+	db.InstanceSet(gormStartTimeKey, time.Now())
+}
+
+func after(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(gormStartTimeKey)
+		if !ok {
+			return
+		}
+		start, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+		err := db.Error
+		if errors.Is(err, gorm.ErrRecordNotFound) { // 查不到记录属于正常业务情况
+			err = nil
+		}
+		DBQueryDuration.WithLabelValues(operation, db.Statement.Table, Status(err)).
+			Observe(time.Since(start).Seconds())
+	}
+}
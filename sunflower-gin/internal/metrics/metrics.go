@@ -0,0 +1,238 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Prometheus 监控指标，统一在这里定义，通过 /metrics 接口暴露
+
+const namespace = "sunflower"
+
+// HTTP 请求
+var (
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP 请求耗时",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+// 业务指标
+var (
+	CheckinTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "checkin",
+		Name:      "daily_total",
+		Help:      "每日签到成功次数",
+	})
+	RetroCheckinTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "checkin",
+		Name:      "retroactive_total",
+		Help:      "补签成功次数",
+	})
+	BonusAwardedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "checkin",
+		Name:      "bonus_awarded_total",
+		Help:      "连续签到奖励发放次数",
+	}, []string{"bonus_type"})
+	PointsIssuedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "points",
+		Name:      "issued_total",
+		Help:      "发放的积分总数",
+	}, []string{"type"})
+	PointsSpentTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "points",
+		Name:      "spent_total",
+		Help:      "消耗的积分总数",
+	}, []string{"type"})
+)
+
+// MySQL 和 Redis
+var (
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "GORM 执行 SQL 的耗时",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table", "status"})
+	RedisCommandDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "redis",
+		Name:      "command_duration_seconds",
+		Help:      "Redis 命令耗时",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"command", "status"})
+)
+
+// 积分转赠
+var (
+	TransferTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "transfer",
+		Name:      "total",
+		Help:      "积分转赠次数，按状态区分",
+	}, []string{"status"})
+	TransferPointsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "transfer",
+		Name:      "points_total",
+		Help:      "转赠成功的积分总数",
+	})
+)
+
+// 营销活动
+var (
+	CampaignPointsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "campaign",
+		Name:      "points_total",
+		Help:      "活动额外发放的积分总数，按活动类型区分",
+	}, []string{"type"})
+	CampaignBudgetExhaustedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "campaign",
+		Name:      "budget_exhausted_total",
+		Help:      "活动奖池余额不足没有发放奖励的次数，按活动类型区分",
+	}, []string{"type"})
+)
+
+// 成就
+var (
+	AchievementUnlockedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "achievement",
+		Name:      "unlocked_total",
+		Help:      "获得成就的次数，按成就编码区分",
+	}, []string{"code"})
+)
+
+// 用户等级
+var (
+	TierChangesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "tier",
+		Name:      "changes_total",
+		Help:      "用户等级变化的次数，direction 为 upgrade 或 downgrade",
+	}, []string{"direction"})
+)
+
+// 邀请
+var (
+	ReferralsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "referral",
+		Name:      "total",
+		Help:      "邀请关系的数量，绑定时按 pending/rejected 计数，发放奖励时按 rewarded/rejected 计数",
+	}, []string{"status"})
+)
+
+// 任务
+var (
+	MissionEventsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "mission",
+		Name:      "events_total",
+		Help:      "收到的任务事件数量，result 为 ok 或 duplicate",
+	}, []string{"result"})
+	MissionClaimsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "mission",
+		Name:      "claims_total",
+		Help:      "领取任务奖励的次数，按任务编码区分",
+	}, []string{"code"})
+)
+
+// 幸运抽奖
+var (
+	DrawsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "draw",
+		Name:      "total",
+		Help:      "抽奖次数，按抽中的奖品类型区分",
+	}, []string{"prize_type"})
+	RetroCardsUsedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "draw",
+		Name:      "retro_cards_used_total",
+		Help:      "补签时使用补签卡的次数",
+	})
+)
+
+// 内部接口
+var (
+	APIKeyAuthTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "apikey",
+		Name:      "auth_total",
+		Help:      "API key 签名认证的次数，result 为 ok 或失败原因",
+	}, []string{"result"})
+	PointsCreditsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "apikey",
+		Name:      "points_credits_total",
+		Help:      "通过内部接口发放积分的请求数，result 为 ok、duplicate 或 conflict",
+	}, []string{"result"})
+)
+
+// 对账
+var (
+	ReconcileDiscrepancies = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "reconcile",
+		Name:      "discrepancies",
+		Help:      "最近一次对账发现的余额不一致的用户数",
+	})
+)
+
+// 缓存
+var (
+	CacheRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "缓存读取次数，按是否命中区分",
+	}, []string{"cache", "result"})
+)
+
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+// 定时任务
+var (
+	CronJobRunsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cron",
+		Name:      "job_runs_total",
+		Help:      "定时任务执行次数",
+	}, []string{"job", "result"})
+	CronJobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "cron",
+		Name:      "job_duration_seconds",
+		Help:      "定时任务执行耗时",
+		Buckets:   []float64{.1, .5, 1, 5, 10, 30, 60, 300},
+	}, []string{"job"})
+)
+
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// Status 根据 err 返回指标的 status 标签值
+func Status(err error) string {
+	if err != nil {
+		return StatusError
+	}
+	return StatusOK
+}
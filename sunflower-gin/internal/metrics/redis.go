@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisHook 统计 Redis 命令耗时的 go-redis hook
+type RedisHook struct{}
+
+var _ redis.Hook = RedisHook{}
+
+func (RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		RedisCommandDuration.WithLabelValues(cmd.Name(), redisStatus(err)).
+			Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+func (RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		RedisCommandDuration.WithLabelValues("pipeline", redisStatus(err)).
+			Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// redisStatus key 不存在（redis.Nil）属于正常情况，不计为错误
+func redisStatus(err error) string {
+	if errors.Is(err, redis.Nil) {
+		return StatusOK
+	}
+	return Status(err)
+}
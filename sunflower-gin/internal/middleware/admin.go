@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"slices"
+
+	"sunflower-gin/api"
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Admin 管理接口权限中间件，必须放在 Auth 之后，只允许配置文件 admin.user_ids 中的用户访问
+func Admin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetInt64(CtxKeyUserID)
+		if !slices.Contains(conf.Get().Admin.UserIDs, userID) {
+			logging.Ctx(c).Sugar().Warnf("user %d is not admin, path: %s", userID, c.FullPath())
+			api.ResponseError(c, api.CodeForbidden)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
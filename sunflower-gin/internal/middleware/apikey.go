@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"sunflower-gin/api"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/apikey"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// 签名请求的请求头
+const (
+	HeaderAPIKey    = "X-Api-Key"
+	HeaderTimestamp = "X-Timestamp" // unix 秒
+	HeaderNonce     = "X-Nonce"     // 调用方生成的随机字符串，最长 64 个字符
+	HeaderSignature = "X-Signature" // hex 编码的 HMAC-SHA256 签名
+
+	CtxKeyAPIKeyID = "apiKeyId" // 调用方的 API key ID 上下文 key
+
+	maxSignedBodySize = 1 << 20 // 签名请求的请求体最大 1MB
+)
+
+// APIKey 服务端 API key 签名认证中间件，key 需要有 scope 的权限
+// 非默认租户的 key 需要同时带上 X-Tenant-ID 等租户标识，只能查到当前租户的 key
+func APIKey(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 签名包含请求体，读出来之后放回去，后面的 handler 还要绑定参数
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSignedBodySize))
+		if err != nil {
+			api.ResponseError(c, api.CodeInvalidParam)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		info, err := apikey.Verify(c, &model.APIKeyVerifyInput{
+			KeyID:     c.GetHeader(HeaderAPIKey),
+			Timestamp: c.GetHeader(HeaderTimestamp),
+			Nonce:     c.GetHeader(HeaderNonce),
+			Signature: c.GetHeader(HeaderSignature),
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.RequestURI(),
+			Body:      body,
+			Scope:     scope,
+		})
+		if err != nil {
+			logging.Ctx(c).Sugar().Warnf("api key auth failed, key: %s, path: %s, ip: %s, err: %v",
+				c.GetHeader(HeaderAPIKey), c.FullPath(), c.ClientIP(), err)
+			api.ResponseErrorWithErr(c, apiKeyErrCode(err), err)
+			c.Abort()
+			return
+		}
+		c.Set(CtxKeyAPIKeyID, info.ID)
+		setLogger(c, logging.Ctx(c).With(zap.String("api_key", info.KeyID)))
+		c.Next()
+	}
+}
+
+// apiKeyErrCode 认证失败对应的错误码，key 没有权限时返回 CodeForbidden
+func apiKeyErrCode(err error) api.ResCode {
+	if errors.Is(err, apikey.ErrScopeDenied) {
+		return api.CodeForbidden
+	}
+	var e *i18n.Error
+	if errors.As(err, &e) {
+		return api.CodeInvalidToken
+	}
+	return api.CodeServerBusy
+}
@@ -4,8 +4,10 @@ import (
 	"strings"
 
 	"sunflower-gin/api"
+	"sunflower-gin/internal/tenant"
 
 	"sunflower-gin/pkg/jwt"
+	"sunflower-gin/pkg/logging"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -34,15 +36,16 @@ func Auth() gin.HandlerFunc {
 			return
 		}
 		tokenString := strings.TrimPrefix(authorizationValue, "Bearer ")
-		claims, err := jwt.ParseAccessToken(tokenString)
+		claims, err := jwt.ParseAccessToken(tokenString, tenant.Issuer(c))
 		if err != nil {
-			zap.L().Sugar().Debugf("parse access token error: %v", err)
+			logging.Ctx(c).Sugar().Debugf("parse access token error: %v", err)
 			api.ResponseError(c, api.CodeInvalidToken)
 			c.Abort()
 			return
 		}
 		// 将用户ID存入上下文，后续中间件或业务逻辑可以直接从上下文中获取
 		c.Set(CtxKeyUserID, claims.UserId)
+		setLogger(c, logging.Ctx(c).With(zap.Int64("user_id", claims.UserId)))
 		c.Next()
 	}
 }
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithoutCancel 请求上下文不随客户端断开而取消
+// 开启 ContextWithFallback 后 gin.Context 会继承 Request.Context() 的取消信号，
+// 签到等写操作执行到一半被取消会导致 Redis 和 MySQL 数据不一致，这里保留 trace 等上下文数据，去掉取消信号
+func WithoutCancel() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(context.WithoutCancel(c.Request.Context()))
+		c.Next()
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"sunflower-gin/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	langQueryKey = "lang" // 用户偏好语言参数，优先级高于 Accept-Language
+)
+
+// Locale 解析当前请求的语言并存入上下文
+// 优先使用 ?lang=en-US 指定的用户偏好，其次是 Accept-Language 请求头
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.Match(c.Query(langQueryKey), c.GetHeader("Accept-Language"))
+		c.Set(i18n.CtxKeyLang, lang)
+		c.Header("Content-Language", lang)
+		c.Next()
+	}
+}
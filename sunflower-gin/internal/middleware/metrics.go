@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"sunflower-gin/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics 统计 HTTP 请求耗时和状态码的中间件
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath() // 使用路由模板作为标签，避免路径参数导致标签爆炸
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.HTTPRequestDuration.
+			WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,32 @@
+-- 滑动窗口限流，窗口内的每次请求记录在 ZSet 中，score 为请求时间(毫秒)
+-- KEYS[1]: 限流key
+-- ARGV[1]: 当前时间(毫秒)
+-- ARGV[2]: 窗口大小(毫秒)
+-- ARGV[3]: 窗口内允许的最大请求数
+-- ARGV[4]: 本次请求的唯一标识
+
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+-- 移除窗口之外的请求记录
+redis.call('ZREMRANGEBYSCORE', KEYS[1], 0, now - window)
+
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+    redis.call('ZADD', KEYS[1], now, ARGV[4])
+    count = count + 1
+    allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], window)
+
+-- 窗口内最早的请求过期后才会空出额度
+local resetAfter = window
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if #oldest > 0 then
+    resetAfter = tonumber(oldest[2]) + window - now
+end
+
+-- 返回结果: {是否放行 1/0, 剩余次数, 多少毫秒后额度恢复}
+return {allowed, limit - count, resetAfter}
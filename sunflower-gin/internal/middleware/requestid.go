@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"regexp"
+
+	"sunflower-gin/api"
+	"sunflower-gin/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderRequestID = "X-Request-ID"
+)
+
+// 上游传入的请求ID只接受常见字符，避免日志注入
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,64}$`)
+
+// RequestID 请求ID中间件
+// 优先使用上游传入的 X-Request-ID，没有时生成一个，并创建带有请求ID的 logger 存入上下文
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(HeaderRequestID)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+		c.Set(api.CtxKeyRequestID, requestID)
+		c.Header(HeaderRequestID, requestID)
+
+		// logging.Ctx 在没有保存 logger 时会带上 trace 信息，这里在其基础上加上请求ID
+		setLogger(c, logging.Ctx(c).With(zap.String("request_id", requestID)))
+		c.Next()
+	}
+}
+
+// setLogger 将 logger 保存到请求上下文中，service 层通过 logging.Ctx(ctx) 取出
+func setLogger(c *gin.Context, l *zap.Logger) {
+	c.Request = c.Request.WithContext(logging.NewContext(c.Request.Context(), l))
+}
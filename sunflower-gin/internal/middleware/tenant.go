@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"sunflower-gin/api"
+	"sunflower-gin/internal/tenant"
+	"sunflower-gin/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderTenantID = "X-Tenant-ID" // 指定请求所属的租户
+	HeaderAppKey   = "X-App-Key"   // 客户端内置的应用标识，按 app key 识别租户
+)
+
+// Tenant 多租户中间件，识别请求所属的租户并放入请求上下文，service 层通过 tenant.FromContext(ctx) 取出
+// 依次按请求头 X-App-Key、X-Tenant-ID 和 Host 识别，都没有匹配时属于默认租户
+func Tenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := tenant.Resolve(c.GetHeader(HeaderAppKey), c.GetHeader(HeaderTenantID), c.Request.Host)
+		if err != nil {
+			api.ResponseErrorWithErr(c, api.CodeInvalidParam, err)
+			c.Abort()
+			return
+		}
+		c.Request = c.Request.WithContext(tenant.WithID(c.Request.Context(), id))
+		if id != tenant.Default {
+			setLogger(c, logging.Ctx(c).With(zap.String("tenant_id", id)))
+		}
+		c.Next()
+	}
+}
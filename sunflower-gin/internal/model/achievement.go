@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// 触发成就计算的事件类型
+type AchievementEventType int32
+
+const (
+	AchievementEventCheckin      AchievementEventType = 1 // 每日签到
+	AchievementEventRetroactive  AchievementEventType = 2 // 补签，消耗积分兑换
+	AchievementEventPointsEarned AchievementEventType = 3 // 签到以外获得积分，例如收到转赠
+)
+
+// AchievementEvent 触发成就计算的事件
+type AchievementEvent struct {
+	UserID     int64
+	Type       AchievementEventType
+	StreakDays int // 截止今天的连续签到天数，只有签到和补签事件有值
+}
+
+// AchievementInfo 成就的定义和用户的进度
+type AchievementInfo struct {
+	Code        string
+	Name        string // 按请求语言翻译后的名称
+	Description string // 按请求语言翻译后的获得条件
+	Target      int64
+	Progress    int64
+	UnlockedAt  *time.Time // 为空时还没有获得
+}
@@ -0,0 +1,71 @@
+package model
+
+import "time"
+
+// 服务端 API key 的接口范围
+const (
+	APIScopePointsCredit = "points:credit" // 给用户发放积分
+	APIScopeCheckinRead  = "checkin:read"  // 查询用户的签到状态
+	APIScopeMissionEvent = "mission:event" // 上报任务事件
+)
+
+// APIScopes 所有的接口范围
+var APIScopes = []string{APIScopePointsCredit, APIScopeCheckinRead, APIScopeMissionEvent}
+
+// APIKeyCreateInput 创建 API key 的参数
+type APIKeyCreateInput struct {
+	Name   string
+	Scopes []string
+}
+
+// APIKeyCreateOutput 新建的 API key，Secret 只在创建时返回
+type APIKeyCreateOutput struct {
+	KeyID  string
+	Secret string
+}
+
+// APIKeyInfo API key 信息，不含 Secret
+type APIKeyInfo struct {
+	ID         int64
+	KeyID      string
+	Name       string
+	Scopes     []string
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// APIKeyVerifyInput 校验签名请求的参数
+type APIKeyVerifyInput struct {
+	KeyID     string
+	Timestamp string // unix 秒
+	Nonce     string
+	Signature string // hex 编码的 HMAC-SHA256
+	Method    string
+	Path      string // 包含查询参数
+	Body      []byte
+	Scope     string // 接口需要的范围
+}
+
+// PointsCreditInput 内部接口发放积分的参数
+type PointsCreditInput struct {
+	APIKeyID  int64
+	RequestID string // 调用方生成的请求ID，同一个 key 重复提交只发放一次
+	UserID    int64
+	Points    int64
+	Reason    string
+}
+
+// PointsCreditOutput 发放积分的结果
+type PointsCreditOutput struct {
+	Duplicate bool // 请求ID已经处理过，这次没有发放
+	CreditID  int64
+	Points    int64
+}
+
+// CheckinStatusOutput 用户当前的签到状态
+type CheckinStatusOutput struct {
+	IsCheckedInToday bool
+	ConsecutiveDays  int // 截止到今天的连续签到天数，可以跨月
+	MonthDays        int // 本月签到的天数，包含补签
+}
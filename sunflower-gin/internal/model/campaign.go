@@ -0,0 +1,127 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// 营销活动类型
+type CampaignType int32
+
+const (
+	CampaignTypeMultiplier  CampaignType = 1 // 签到积分倍率，例如周末双倍积分
+	CampaignTypeCheckinGoal CampaignType = 2 // 签到天数目标，活动期间签到满指定天数一次性发放奖励
+)
+
+// String 活动类型的名称，用于日志和监控指标的标签
+func (t CampaignType) String() string {
+	switch t {
+	case CampaignTypeMultiplier:
+		return "multiplier"
+	case CampaignTypeCheckinGoal:
+		return "checkin_goal"
+	default:
+		return "unknown"
+	}
+}
+
+// 营销活动状态
+type CampaignStatus int32
+
+const (
+	CampaignStatusEnabled  CampaignStatus = 1 // 启用
+	CampaignStatusDisabled CampaignStatus = 2 // 停用
+)
+
+// CampaignRules 活动的参与条件，序列化后存在 RulesJSON 字段中，零值表示不限制
+type CampaignRules struct {
+	Weekdays          []int `json:"weekdays,omitempty"`          // 只在每周的这几天生效，1-7 表示周一到周日
+	NewUserDays       int   `json:"newUserDays,omitempty"`       // 只有注册不超过这么多天的用户可以参与
+	MinLifetimePoints int64 `json:"minLifetimePoints,omitempty"` // 累计获得的积分达到这个数量才能参与
+}
+
+// Marshal 序列化为 RulesJSON 字段的值
+func (r CampaignRules) Marshal() (string, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ParseCampaignRules 解析 RulesJSON 字段
+func ParseCampaignRules(s string) (*CampaignRules, error) {
+	rules := &CampaignRules{}
+	if s == "" {
+		return rules, nil
+	}
+	if err := json.Unmarshal([]byte(s), rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// CampaignBonus 每日签到参与倍率活动时额外发放的积分
+type CampaignBonus struct {
+	CampaignID   int64
+	CampaignName string
+	Points       int64
+	FromPool     bool // 是否从活动奖池转出，没有设置预算的活动和签到积分一样从系统发放账户转出
+}
+
+// CampaignInput 创建和修改活动的参数
+type CampaignInput struct {
+	ID          int64 // 修改时必填
+	Name        string
+	Type        CampaignType
+	Status      CampaignStatus
+	StartAt     time.Time
+	EndAt       time.Time
+	Multiplier  int32 // 倍率活动的签到积分倍率，百分比
+	GoalDays    int32 // 目标活动的签到天数
+	BonusPoints int64 // 目标活动的奖励积分
+	Budget      int64 // 活动预算，0 表示不限制
+	Rules       CampaignRules
+}
+
+// CampaignInfo 管理后台看到的活动信息
+type CampaignInfo struct {
+	ID              int64
+	Name            string
+	Type            CampaignType
+	Status          CampaignStatus
+	StartAt         time.Time
+	EndAt           time.Time
+	Multiplier      int32
+	GoalDays        int32
+	BonusPoints     int64
+	Budget          int64
+	RemainingBudget int64 // 活动奖池的余额，没有设置预算时为 0
+	Rules           CampaignRules
+	CreatedAt       time.Time
+}
+
+type CampaignListInput struct {
+	Status CampaignStatus // 为 0 时不过滤
+	Offset int
+	Limit  int
+}
+
+type CampaignListOutput struct {
+	Total int64
+	List  []*CampaignInfo
+}
+
+// UserCampaignInfo 用户可以参与的进行中的活动和目标进度
+type UserCampaignInfo struct {
+	ID          int64
+	Name        string
+	Type        CampaignType
+	StartAt     time.Time
+	EndAt       time.Time
+	Multiplier  int32
+	GoalDays    int32
+	BonusPoints int64
+	CheckinDays int32 // 目标活动中已经签到的天数
+	Completed   bool  // 目标活动是否已经达成
+}
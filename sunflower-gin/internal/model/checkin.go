@@ -5,5 +5,6 @@ type MonthDetailOutput struct {
 	RetroCheckedInDays []int `json:"retroCheckedInDays"` // 补签的日期序号
 	IsCheckedInToday   bool  `json:"isCheckedInToday"`   // 今天是否签到
 	RemainRetroTimes   int   `json:"remainRetroTimes"`   // 剩余补签次数
+	RetroCards         int64 `json:"retroCards"`         // 剩余补签卡张数
 	ConsecutiveDays    int   `json:"consecutiveDays"`    // 连续签到天数
 }
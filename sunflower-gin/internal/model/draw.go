@@ -0,0 +1,90 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+// 抽奖奖品类型
+type DrawPrizeType int32
+
+const (
+	DrawPrizeTypePoints    DrawPrizeType = 1 // 积分
+	DrawPrizeTypeRetroCard DrawPrizeType = 2 // 补签卡
+	DrawPrizeTypeCoupon    DrawPrizeType = 3 // 优惠券
+	DrawPrizeTypeNone      DrawPrizeType = 4 // 谢谢参与
+)
+
+// String 奖品类型的名称，和配置文件中的 type 一致，也用于监控指标的标签
+func (t DrawPrizeType) String() string {
+	switch t {
+	case DrawPrizeTypePoints:
+		return "points"
+	case DrawPrizeTypeRetroCard:
+		return "retro_card"
+	case DrawPrizeTypeCoupon:
+		return "coupon"
+	case DrawPrizeTypeNone:
+		return "none"
+	default:
+		return strconv.Itoa(int(t))
+	}
+}
+
+// ParseDrawPrizeType 配置文件中的奖品类型，不认识的类型返回 0
+func ParseDrawPrizeType(s string) DrawPrizeType {
+	for _, t := range []DrawPrizeType{DrawPrizeTypePoints, DrawPrizeTypeRetroCard, DrawPrizeTypeCoupon, DrawPrizeTypeNone} {
+		if t.String() == s {
+			return t
+		}
+	}
+	return 0
+}
+
+// DrawPrizeInfo 奖品池中的奖品
+type DrawPrizeInfo struct {
+	Code        string
+	Name        string // 按请求语言翻译后的名称
+	Type        DrawPrizeType
+	Amount      int64
+	Probability float64 // 抽中的概率，库存不足的奖品为 0
+	Stock       int64   // 总库存，0 表示不限制
+	Remaining   int64   // 剩余库存，不限制库存时为 0
+}
+
+// DrawPoolOutput 奖品池和当前用户今天的抽奖状态
+type DrawPoolOutput struct {
+	Enabled   bool
+	CanDraw   bool      // 今天已经签到、还没有抽奖并且还有奖品
+	Today     *DrawInfo // 今天的抽奖结果，还没有抽奖时为空
+	RetroCard int64     // 剩余的补签卡张数
+	Prizes    []*DrawPrizeInfo
+}
+
+// DrawInfo 一次抽奖的结果和随机数种子
+type DrawInfo struct {
+	ID              int64
+	DrawDate        time.Time
+	PrizeCode       string
+	PrizeName       string // 按请求语言翻译后的名称
+	PrizeType       DrawPrizeType
+	Amount          int64
+	CouponCode      string
+	CouponExpiresAt *time.Time
+	ServerSeed      string
+	Nonce           int32
+	Roll            int64
+	TotalWeight     int64
+	CreatedAt       time.Time
+}
+
+type DrawListInput struct {
+	UserID int64
+	Offset int
+	Limit  int
+}
+
+type DrawListOutput struct {
+	Total int64
+	List  []*DrawInfo
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// CheckResult 依赖检查结果
+type CheckResult struct {
+	Up      bool
+	Latency time.Duration
+	Err     error
+}
@@ -0,0 +1,61 @@
+package model
+
+import "strconv"
+
+// 记账账户类型
+type LedgerAccountType int32
+
+const (
+	LedgerAccountTypeUserWallet   LedgerAccountType = 1 // 用户钱包，OwnerID 为用户ID
+	LedgerAccountTypeIssuance     LedgerAccountType = 2 // 系统发放，签到奖励等凭空发放的积分从这里转出，不维护余额
+	LedgerAccountTypeCampaignPool LedgerAccountType = 3 // 活动奖池，OwnerID 为活动ID
+	LedgerAccountTypeBurn         LedgerAccountType = 4 // 销毁，补签等消耗掉的积分转入这里
+)
+
+// String 账户类型的名称，用于日志和监控指标的标签
+func (t LedgerAccountType) String() string {
+	switch t {
+	case LedgerAccountTypeUserWallet:
+		return "user_wallet"
+	case LedgerAccountTypeIssuance:
+		return "issuance"
+	case LedgerAccountTypeCampaignPool:
+		return "campaign_pool"
+	case LedgerAccountTypeBurn:
+		return "burn"
+	default:
+		return strconv.Itoa(int(t))
+	}
+}
+
+// AllowNegative 余额是否可以为负数，只有系统发放账户可以透支
+func (t LedgerAccountType) AllowNegative() bool {
+	return t == LedgerAccountTypeIssuance
+}
+
+// LedgerEntryInput 一笔记账分录
+type LedgerEntryInput struct {
+	Type     PointsTransactionType
+	DescKey  string // 描述信息的多语言 key
+	DescArgs []any  // 描述信息的格式化参数
+	Ext      TransactionExt
+	Lines    []*LedgerLine // 至少两行，所有行的金额之和必须为 0
+}
+
+// LedgerLine 分录中的一行，表示一个账户的积分变动
+type LedgerLine struct {
+	AccountType LedgerAccountType
+	OwnerID     int64
+	Amount      int64 // 正数转入该账户，负数从该账户转出，不能为 0
+
+	// 以下字段只对用户钱包有效，用于生成用户的积分流水，为空时使用分录上的值
+	TransactionType PointsTransactionType
+	DescKey         string
+	DescArgs        []any
+}
+
+// LedgerEntryOutput 记账结果
+type LedgerEntryOutput struct {
+	EntryID      int64
+	Transactions []*UserPointsTransaction // 与 Lines 一一对应，只有用户钱包的行有值
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// MissionEventInput 其它服务上报的任务事件
+type MissionEventInput struct {
+	EventID string // 上报方生成的事件ID，重复上报时只处理一次
+	UserID  int64
+	Event   string
+	Count   int64
+}
+
+// MissionEventOutput 任务事件的处理结果
+type MissionEventOutput struct {
+	Duplicate bool     // 事件已经处理过
+	Completed []string // 这次事件完成的任务编码
+}
+
+// MissionInfo 任务定义和用户在当前周期的进度
+type MissionInfo struct {
+	Code        string
+	Name        string // 按请求语言翻译后的名称
+	Period      string // daily/weekly/once
+	Target      int64
+	Points      int64
+	Progress    int64
+	CompletedAt *time.Time
+	ClaimedAt   *time.Time
+	ResetAt     *time.Time // 下一次重置的时间，一次性任务为空
+}
+
+// MissionClaimOutput 领取任务奖励的结果
+type MissionClaimOutput struct {
+	Code   string
+	Points int64
+}
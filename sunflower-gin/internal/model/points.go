@@ -1,11 +1,108 @@
 package model
 
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// 积分变更记录表的交易类型
+type PointsTransactionType int32
+
+const (
+	PointsTransactionTypeDaily       PointsTransactionType = 1  // 每日签到 1
+	PointsTransactionTypeConsecutive PointsTransactionType = 2  // 连续签到 2
+	PointsTransactionTypeRetroactive PointsTransactionType = 3  // 补签 3
+	PointsTransactionTypeCorrection  PointsTransactionType = 4  // 对账校正 4
+	PointsTransactionTypeTransferOut PointsTransactionType = 5  // 转赠转出 5
+	PointsTransactionTypeTransferIn  PointsTransactionType = 6  // 转赠转入 6
+	PointsTransactionTypeOpening     PointsTransactionType = 7  // 期初余额 7，只用于记账分录，不生成用户积分流水
+	PointsTransactionTypeCampaign    PointsTransactionType = 8  // 活动奖励 8
+	PointsTransactionTypeFunding     PointsTransactionType = 9  // 活动预算 9，系统发放账户和活动奖池之间划转，只用于记账分录
+	PointsTransactionTypeReferral    PointsTransactionType = 10 // 邀请奖励 10
+	PointsTransactionTypeMission     PointsTransactionType = 11 // 任务奖励 11
+	PointsTransactionTypeDraw        PointsTransactionType = 12 // 幸运抽奖 12
+	PointsTransactionTypeCredit      PointsTransactionType = 13 // 其它服务通过内部接口发放 13
+)
+
+// String 交易类型的名称，用于监控指标的标签
+func (t PointsTransactionType) String() string {
+	switch t {
+	case PointsTransactionTypeDaily:
+		return "daily"
+	case PointsTransactionTypeConsecutive:
+		return "consecutive"
+	case PointsTransactionTypeRetroactive:
+		return "retroactive"
+	case PointsTransactionTypeCorrection:
+		return "correction"
+	case PointsTransactionTypeTransferOut:
+		return "transfer_out"
+	case PointsTransactionTypeTransferIn:
+		return "transfer_in"
+	case PointsTransactionTypeOpening:
+		return "opening"
+	case PointsTransactionTypeCampaign:
+		return "campaign"
+	case PointsTransactionTypeFunding:
+		return "funding"
+	case PointsTransactionTypeReferral:
+		return "referral"
+	case PointsTransactionTypeMission:
+		return "mission"
+	case PointsTransactionTypeDraw:
+		return "draw"
+	case PointsTransactionTypeCredit:
+		return "credit"
+	default:
+		return strconv.Itoa(int(t))
+	}
+}
+
 // AddPointInput 添加积分输入参数
 type AddPointInput struct {
 	UserID      int64
 	PointAmount int64
 	Type        int32
-	Desc        string
+	DescKey     string // 描述信息的多语言 key
+	DescArgs    []any  // 描述信息的格式化参数
+
+	Bonus *CampaignBonus // 倍率活动额外发放的积分，和签到积分记在同一笔流水中，为空时没有参与活动
+}
+
+// TransactionExt 积分变更记录的扩展信息，序列化后存在 ExtJSON 字段中
+type TransactionExt struct {
+	DescKey    string `json:"descKey,omitempty"`    // 描述信息的多语言 key
+	DescArgs   []any  `json:"descArgs,omitempty"`   // 描述信息的格式化参数
+	TransferNo int64  `json:"transferNo,omitempty"` // 转赠单号，转出和转入两条流水通过它关联
+	EntryID    int64  `json:"entryId,omitempty"`    // 记账分录ID
+	CampaignID int64  `json:"campaignId,omitempty"` // 参与的营销活动ID
+	ReferralID int64  `json:"referralId,omitempty"` // 邀请关系ID，邀请人和被邀请人的奖励流水通过它关联
+	Mission    string `json:"mission,omitempty"`    // 领取奖励的任务编码
+	DrawID     int64  `json:"drawId,omitempty"`     // 抽奖记录ID
+	CreditID   int64  `json:"creditId,omitempty"`   // 内部接口发放积分的记录ID
+	RetroCard  bool   `json:"retroCard,omitempty"`  // 补签时使用了补签卡
+}
+
+// Marshal 序列化为 ExtJSON 字段的值
+func (e TransactionExt) Marshal() (string, error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ParseTransactionExt 解析 ExtJSON 字段，历史数据可能为空
+func ParseTransactionExt(s string) (*TransactionExt, error) {
+	ext := &TransactionExt{}
+	if s == "" {
+		return ext, nil
+	}
+	if err := json.Unmarshal([]byte(s), ext); err != nil {
+		return nil, err
+	}
+	return ext, nil
 }
 
 type SummaryOutput struct {
@@ -13,15 +110,22 @@ type SummaryOutput struct {
 }
 
 type RecordsInput struct {
-	UserID int64
-	Offset int
-	Limit  int
+	UserID    int64
+	Cursor    string // 上一页返回的 NextCursor，为空时从第一页开始
+	Offset    int    // Deprecated: 旧版本的偏移量分页，传了 Cursor 时忽略
+	Limit     int
+	Types     []int32   // 交易类型，为空时不过滤
+	Sign      string    // earn 只查收入，spend 只查支出，为空时不过滤
+	StartTime time.Time // 开始时间（包含），零值不过滤
+	EndTime   time.Time // 结束时间（不包含），零值不过滤
+	WithTotal bool      // 是否统计总数
 }
 
 type RecordsOutput struct {
-	Total   int64
-	HasMore bool
-	List    []*RecordInfo
+	Total      *int64 // WithTotal 为 true 时才有值
+	HasMore    bool
+	NextCursor string
+	List       []*RecordInfo
 }
 
 type RecordInfo struct {
@@ -30,3 +134,49 @@ type RecordInfo struct {
 	Description     string
 	TransactionTime string
 }
+
+type StatsInput struct {
+	UserID      int64
+	Granularity string    // 时间序列的统计粒度 day/week/month
+	StartTime   time.Time // 开始时间（包含），零值时按粒度取默认范围
+	EndTime     time.Time // 结束时间（不包含），零值时统计到当前周期
+}
+
+type StatsOutput struct {
+	Balance        int64 // 当前余额
+	LifetimeEarned int64 // 累计获得
+	LifetimeSpent  int64 // 累计消耗
+	ByType         []*TypeStats
+	Granularity    string
+	Series         []*SeriesPoint
+}
+
+// TypeStats 按交易类型汇总的积分
+type TypeStats struct {
+	TransactionType int32
+	Earned          int64
+	Spent           int64
+	Count           int64
+}
+
+// SeriesPoint 时间序列中一个周期的积分汇总
+type SeriesPoint struct {
+	Period string // 周期名称，日和周为 2006-01-02（周一），月为 2006-01
+	Earned int64
+	Spent  int64
+}
+
+type ExportInput struct {
+	UserID    int64
+	StartTime time.Time // 开始时间（包含），零值不过滤
+	EndTime   time.Time // 结束时间（不包含），零值不过滤
+}
+
+// ExportRow 导出文件中的一行
+type ExportRow struct {
+	TransactionTime time.Time
+	TransactionType int32
+	PointsChange    int64
+	CurrentBalance  int64 // 这条记录之后的余额
+	Description     string
+}
@@ -0,0 +1,40 @@
+package model
+
+type ReconcileInput struct {
+	UserIDs []int64 // 为空时检查所有用户
+	Repair  bool    // 是否记账校正修复余额不一致
+}
+
+type ReconcileOutput struct {
+	Checked       int                // 检查的用户数
+	Found         int                // 有问题的用户数
+	Repaired      int                // 修复的用户数
+	Discrepancies []*ReconcileResult // 有问题的用户，最多保留 1000 个
+}
+
+// ReconcileResult 单个用户的对账结果
+type ReconcileResult struct {
+	UserID         int64
+	Balance        int64         // user_points 表中的余额
+	AccountBalance int64         // 用户钱包 ledger_accounts 中的余额，还没有钱包时与 LedgerBalance 相同
+	LedgerBalance  int64         // 钱包所有记账明细累加得到的余额，还没有钱包时为所有流水累加得到的余额
+	ChainBreaks    []*ChainBreak // 余额链断裂的流水
+	Repaired       bool          // 是否已经记账校正
+}
+
+// Balanced user_points 和钱包的余额都与记账明细一致
+func (r *ReconcileResult) Balanced() bool {
+	return r.Balance == r.LedgerBalance && r.AccountBalance == r.LedgerBalance
+}
+
+// OK 余额和余额链都没有问题
+func (r *ReconcileResult) OK() bool {
+	return r.Balanced() && len(r.ChainBreaks) == 0
+}
+
+// ChainBreak 流水记录的 CurrentBalance 与之前所有流水累加的结果不一致
+type ChainBreak struct {
+	TransactionID int64
+	Expected      int64 // 之前所有流水累加的结果
+	Actual        int64 // 流水中记录的 CurrentBalance
+}
@@ -0,0 +1,77 @@
+package model
+
+import "time"
+
+// 邀请关系的状态
+type ReferralStatus int32
+
+const (
+	ReferralStatusPending  ReferralStatus = 1 // 等待被邀请人完成签到
+	ReferralStatusRewarded ReferralStatus = 2 // 已发放奖励
+	ReferralStatusRejected ReferralStatus = 3 // 触发防刷规则，不发放奖励
+)
+
+// String 邀请状态的名称，用于监控指标的标签
+func (s ReferralStatus) String() string {
+	switch s {
+	case ReferralStatusPending:
+		return "pending"
+	case ReferralStatusRewarded:
+		return "rewarded"
+	case ReferralStatusRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// 不发放邀请奖励的原因
+const (
+	ReferralRejectIPLimit      = "ip_limit"      // 同一IP注册过多
+	ReferralRejectDeviceLimit  = "device_limit"  // 同一设备注册过多
+	ReferralRejectInviterLimit = "inviter_limit" // 邀请人获得奖励的次数达到上限
+)
+
+// ReferralBindInput 新用户注册时绑定邀请关系
+type ReferralBindInput struct {
+	InviterID  int64
+	InviteeID  int64
+	RegisterIP string
+	DeviceID   string
+}
+
+// ReferralDashboard 邀请人看到的邀请信息和统计
+type ReferralDashboard struct {
+	Code             string
+	RequiredCheckins int32 // 被邀请人需要完成的签到次数
+	InviterPoints    int64 // 每邀请一人可以获得的积分
+	InviteePoints    int64 // 被邀请人可以获得的积分
+	Invited          int64 // 邀请的总人数
+	Pending          int64
+	Rewarded         int64
+	Rejected         int64
+	EarnedPoints     int64 // 已经获得的邀请奖励积分
+}
+
+// ReferralInviteeListInput 邀请记录查询参数
+type ReferralInviteeListInput struct {
+	UserID int64
+	Offset int
+	Limit  int
+}
+
+// ReferralInviteeListOutput 邀请记录，按注册时间倒序
+type ReferralInviteeListOutput struct {
+	Total int64
+	List  []*ReferralInviteeInfo
+}
+
+// ReferralInviteeInfo 一个被邀请人
+type ReferralInviteeInfo struct {
+	Username      string
+	Status        ReferralStatus
+	Checkins      int32 // 注册后完成的每日签到次数
+	InviterPoints int64 // 邀请人获得的奖励积分，没有发放时为 0
+	RegisteredAt  time.Time
+	RewardedAt    *time.Time
+}
@@ -0,0 +1,65 @@
+package model
+
+import "time"
+
+// TierPerks 用户当前等级的权益
+type TierPerks struct {
+	Level            int32
+	DailyPointsBonus int64 // 每日签到额外获得的积分
+	ExtraRetroTimes  int   // 每月额外的补签次数
+}
+
+// TierInfo 用户当前等级和升级进度
+type TierInfo struct {
+	Level          int32
+	Name           string           // 按请求语言翻译后的名称，没有等级时为空
+	LifetimePoints int64            // 累计获得的积分
+	BestStreak     int32            // 最长连续签到天数
+	Next           *TierRequirement // 下一个等级，已经是最高等级时为空
+}
+
+// TierRequirement 达到某个等级的条件，满足任一条件即可，为 0 的条件不计算
+type TierRequirement struct {
+	Level             int32
+	Name              string
+	MinLifetimePoints int64
+	MinStreakDays     int
+}
+
+// TierHistoryListInput 等级变化记录查询参数
+type TierHistoryListInput struct {
+	UserID int64
+	Offset int
+	Limit  int
+}
+
+// TierHistoryListOutput 等级变化记录，按时间倒序
+type TierHistoryListOutput struct {
+	Total int64
+	List  []*TierHistoryInfo
+}
+
+// TierHistoryInfo 一次等级变化
+type TierHistoryInfo struct {
+	FromLevel      int32
+	FromName       string
+	ToLevel        int32
+	ToName         string
+	LifetimePoints int64
+	BestStreak     int32
+	CreatedAt      time.Time
+}
+
+// TierChange 一次等级计算的结果
+type TierChange struct {
+	UserID    int64
+	FromLevel int32
+	ToLevel   int32
+}
+
+// TierEvaluateOutput 批量计算等级的结果
+type TierEvaluateOutput struct {
+	Checked    int
+	Upgraded   int
+	Downgraded int
+}
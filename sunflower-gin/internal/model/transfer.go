@@ -0,0 +1,65 @@
+package model
+
+import "time"
+
+// 积分转赠的状态
+type PointsTransferStatus int32
+
+const (
+	PointsTransferStatusPending   PointsTransferStatus = 1 // 待确认
+	PointsTransferStatusCompleted PointsTransferStatus = 2 // 已完成
+	PointsTransferStatusExpired   PointsTransferStatus = 3 // 已过期
+)
+
+// String 转赠状态的名称，用于监控指标的标签
+func (s PointsTransferStatus) String() string {
+	switch s {
+	case PointsTransferStatusPending:
+		return "pending"
+	case PointsTransferStatusCompleted:
+		return "completed"
+	case PointsTransferStatusExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+type CreateTransferInput struct {
+	FromUserID int64
+	ToUsername string
+	Amount     int64
+	Remark     string
+}
+
+type ConfirmTransferInput struct {
+	UserID     int64
+	TransferNo int64
+	Password   string // 当前用户的登录密码
+}
+
+// TransferInfo 从当前用户的角度看到的一笔转赠
+type TransferInfo struct {
+	TransferNo   int64
+	Direction    string // in 转入，out 转出
+	Counterparty string // 对方的用户名
+	Amount       int64
+	Status       int32
+	Remark       string
+	CreatedAt    time.Time
+	CompletedAt  *time.Time
+	ExpireAt     *time.Time // 待确认的转赠的过期时间
+}
+
+type TransferListInput struct {
+	UserID    int64
+	Direction string // in 只查转入，out 只查转出，为空时都查
+	Cursor    string // 上一页返回的 NextCursor，为空时从第一页开始
+	Limit     int
+}
+
+type TransferListOutput struct {
+	HasMore    bool
+	NextCursor string
+	List       []*TransferInfo
+}
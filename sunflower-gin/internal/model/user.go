@@ -4,6 +4,10 @@ type CreateUserInput struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 	Email    string `json:"email"`
+
+	InviteCode string `json:"inviteCode"` // 邀请码，为空时没有邀请人
+	ClientIP   string `json:"-"`          // 注册时的IP，用于邀请的防刷
+	DeviceID   string `json:"-"`          // 注册时的设备ID，用于邀请的防刷
 }
 
 type CreateUserOutput struct {
@@ -11,6 +15,12 @@ type CreateUserOutput struct {
 	Username string `json:"username"`
 }
 
+type UpdateProfileInput struct {
+	UserID int64
+	Email  string // 为空时不修改
+	Avatar string // 为空时不修改
+}
+
 type UserProfileOutput struct {
 	UserId   int64  `json:"userId"`
 	Username string `json:"username"`
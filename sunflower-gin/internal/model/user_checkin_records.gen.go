@@ -14,8 +14,9 @@ const TableNameUserCheckinRecord = "user_checkin_records"
 
 // UserCheckinRecord mapped from table <user_checkin_records>
 type UserCheckinRecord struct {
-	ID                int64          `gorm:"column:id;primaryKey;autoIncrement:true;comment:ID" json:"id"` // ID
-	UserID            int64          `gorm:"column:user_id;not null;comment:ID" json:"user_id"`            // ID
+	ID                int64          `gorm:"column:id;primaryKey;autoIncrement:true;comment:ID" json:"id"`            // ID
+	TenantID          string         `gorm:"column:tenant_id;not null;default:default;comment:租户ID" json:"tenant_id"` // 租户ID
+	UserID            int64          `gorm:"column:user_id;not null;comment:ID" json:"user_id"`                       // ID
 	CheckinDate       time.Time      `gorm:"column:checkin_date;not null" json:"checkin_date"`
 	CheckinType       int32          `gorm:"column:checkin_type;not null;default:1;comment:1=2=" json:"checkin_type"` // 1=2=
 	PointsAwardedBase int32          `gorm:"column:points_awarded_base;not null;default:1" json:"points_awarded_base"`
@@ -14,8 +14,9 @@ const TableNameUserPoint = "user_points"
 
 // UserPoint mapped from table <user_points>
 type UserPoint struct {
-	ID          int64          `gorm:"column:id;primaryKey;autoIncrement:true;comment:ID" json:"id"` // ID
-	UserID      int64          `gorm:"column:user_id;not null;comment:ID" json:"user_id"`            // ID
+	ID          int64          `gorm:"column:id;primaryKey;autoIncrement:true;comment:ID" json:"id"`            // ID
+	TenantID    string         `gorm:"column:tenant_id;not null;default:default;comment:租户ID" json:"tenant_id"` // 租户ID
+	UserID      int64          `gorm:"column:user_id;not null;comment:ID" json:"user_id"`                       // ID
 	Points      int64          `gorm:"column:points" json:"points"`
 	PointsTotal int64          `gorm:"column:points_total" json:"points_total"`
 	CreatedAt   time.Time      `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
@@ -14,9 +14,10 @@ const TableNameUserPointsTransaction = "user_points_transactions"
 
 // UserPointsTransaction mapped from table <user_points_transactions>
 type UserPointsTransaction struct {
-	ID              int64          `gorm:"column:id;primaryKey;autoIncrement:true;comment:ID" json:"id"`   // ID
-	UserID          int64          `gorm:"column:user_id;not null;comment:ID" json:"user_id"`              // ID
-	PointsChange    int64          `gorm:"column:points_change;not null;comment: ()" json:"points_change"` //  ()
+	ID              int64          `gorm:"column:id;primaryKey;autoIncrement:true;comment:ID" json:"id"`            // ID
+	TenantID        string         `gorm:"column:tenant_id;not null;default:default;comment:租户ID" json:"tenant_id"` // 租户ID
+	UserID          int64          `gorm:"column:user_id;not null;comment:ID" json:"user_id"`                       // ID
+	PointsChange    int64          `gorm:"column:points_change;not null;comment: ()" json:"points_change"`          //  ()
 	CurrentBalance  int64          `gorm:"column:current_balance;not null" json:"current_balance"`
 	TransactionType int32          `gorm:"column:transaction_type;not null;comment:(1: 2: 3: 4: 5:)" json:"transaction_type"` // (1: 2: 3: 4: 5:)
 	Description     string         `gorm:"column:description" json:"description"`
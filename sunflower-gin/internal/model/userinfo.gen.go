@@ -14,8 +14,9 @@ const TableNameUserinfo = "userinfo"
 
 // Userinfo mapped from table <userinfo>
 type Userinfo struct {
-	ID        int64          `gorm:"column:id;primaryKey;autoIncrement:true;comment:ID" json:"id"` // ID
-	UserID    int64          `gorm:"column:user_id;not null;comment:ID" json:"user_id"`            // ID
+	ID        int64          `gorm:"column:id;primaryKey;autoIncrement:true;comment:ID" json:"id"`            // ID
+	TenantID  string         `gorm:"column:tenant_id;not null;default:default;comment:租户ID" json:"tenant_id"` // 租户ID
+	UserID    int64          `gorm:"column:user_id;not null;comment:ID" json:"user_id"`                       // ID
 	Username  string         `gorm:"column:username;not null" json:"username"`
 	Password  string         `gorm:"column:password;not null;comment:(MD5)" json:"password"` // (MD5)
 	Email     string         `gorm:"column:email" json:"email"`
@@ -3,37 +3,72 @@ package server
 import (
 	"net/http"
 
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/handler/achievement"
 	"sunflower-gin/internal/handler/auth"
+	"sunflower-gin/internal/handler/campaign"
 	"sunflower-gin/internal/handler/checkin"
+	"sunflower-gin/internal/handler/draw"
+	"sunflower-gin/internal/handler/health"
+	"sunflower-gin/internal/handler/mission"
 	"sunflower-gin/internal/handler/points"
+	"sunflower-gin/internal/handler/referral"
 	"sunflower-gin/internal/handler/user"
 	"sunflower-gin/internal/middleware"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/logging"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"github.com/spf13/viper"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
-func SetupRoutes(cfg *viper.Viper) *gin.Engine {
-	r := gin.Default()
+func SetupRoutes(cfg *conf.Config) *gin.Engine {
+	r := gin.New()
+	// gin.Context 的 Value 回退到 Request.Context()，service 层拿到的 ctx 才能取到 trace 信息
+	r.ContextWithFallback = true
+	// 链路追踪，健康检查和监控接口不记录
+	r.Use(otelgin.Middleware(cfg.Server.Name, otelgin.WithFilter(func(req *http.Request) bool {
+		switch req.URL.Path {
+		case "/ping", "/metrics", "/livez", "/healthz", "/readyz":
+			return false
+		}
+		return true
+	})))
+	r.Use(middleware.WithoutCancel())
+	r.Use(middleware.RequestID(), logging.GinLogger(), logging.GinRecovery(true)) // 请求ID、访问日志和 panic 恢复
 	r.GET("/ping", func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"message": "pong",
 		})
 	})
+	r.GET("/metrics", gin.WrapH(promhttp.Handler())) // Prometheus 监控指标
+	// 健康检查
+	version := cfg.Server.Version
+	r.GET("/livez", health.LivezHandler(version))     // 存活探针
+	r.GET("/healthz", health.HealthzHandler(version)) // 检查所有依赖
+	r.GET("/readyz", health.ReadyzHandler(version))   // 就绪探针
 	corsCfg := cors.DefaultConfig()
-	corsCfg.AllowHeaders = append(corsCfg.AllowHeaders, "Authorization")
+	corsCfg.AllowHeaders = append(corsCfg.AllowHeaders, "Authorization", "Accept-Language", middleware.HeaderRequestID, middleware.HeaderTenantID, middleware.HeaderAppKey, user.HeaderDeviceID)
+	corsCfg.ExposeHeaders = append(corsCfg.ExposeHeaders, middleware.HeaderRequestID, "Content-Disposition", "Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset")
 	corsCfg.AllowAllOrigins = true // 允许所有跨域请求，不建议在生产环境使用
 	r.Use(cors.New(corsCfg))       // CORS 跨域中间件，简单粗暴，直接放行所有跨域请求
+	r.Use(middleware.Locale())     // 多语言中间件，解析请求语言
+	r.Use(middleware.Metrics())    // 请求耗时监控
+	r.Use(middleware.Tenant())     // 多租户中间件，识别请求所属的租户
 	apiV1 := r.Group("/api/v1")
 	{
-		apiV1.POST("/users", user.CreateHandler)     // 创建用户
-		apiV1.POST("/auth/login", auth.LoginHandler) // 用户登录
-		apiV1.POST("/auth/refresh", auth.RefreshHandler)
+		apiV1.POST("/users", middleware.RateLimit("register"), user.CreateHandler)  // 创建用户
+		apiV1.POST("/auth/login", middleware.RateLimit("login"), auth.LoginHandler) // 用户登录
+		apiV1.POST("/auth/refresh", middleware.RateLimit("login"), auth.RefreshHandler)
 
-		apiV1.Use(middleware.Auth()) // 注册认证中间件
+		apiV1.Use(middleware.Auth())            // 注册认证中间件
+		apiV1.Use(middleware.RateLimit("user")) // 按用户限流
 		// 在这个Auth中间件后面的都需要认证通过才能访问
-		apiV1.GET("/users/me", user.ProfileHandler) // 获取当前用户信息
+		apiV1.GET("/users/me", user.ProfileHandler)                  // 获取当前用户信息
+		apiV1.PUT("/users/me", user.UpdateProfileHandler)            // 修改当前用户信息
+		apiV1.GET("/users/me/tier-history", user.TierHistoryHandler) // 等级变化记录
 
 		// checkin api group
 		checkinGroup := apiV1.Group("/checkins")
@@ -47,7 +82,58 @@ func SetupRoutes(cfg *viper.Viper) *gin.Engine {
 		{
 			pointsGroup.GET("/summary", points.SummaryHandler)
 			pointsGroup.GET("/records", points.RecordsHandler)
+			pointsGroup.GET("/records/export", middleware.RateLimit("export"), points.ExportHandler)
+			pointsGroup.GET("/stats", points.StatsHandler)
+			pointsGroup.GET("/transfers", points.TransferListHandler)
+			pointsGroup.POST("/transfers", middleware.RateLimit("transfer"), points.TransferHandler)
+			pointsGroup.POST("/transfers/:transferNo/confirm", middleware.RateLimit("transfer"), points.ConfirmTransferHandler)
+		}
+		apiV1.GET("/campaigns", campaign.UserListHandler) // 可以参与的活动和目标进度
+		// referral api group
+		referralGroup := apiV1.Group("/referrals")
+		{
+			referralGroup.GET("", referral.DashboardHandler)
+			referralGroup.GET("/invitees", referral.InviteeListHandler)
+		}
+		// achievement api group
+		achievementGroup := apiV1.Group("/achievements")
+		{
+			achievementGroup.GET("", achievement.ListHandler)
+			achievementGroup.GET("/notifications", achievement.NotificationListHandler)
+			achievementGroup.POST("/notifications/ack", achievement.AckNotificationsHandler)
+		}
+		// draw api group
+		drawGroup := apiV1.Group("/draws")
+		{
+			drawGroup.GET("", draw.ListHandler)
+			drawGroup.POST("", draw.DrawHandler)
+			drawGroup.GET("/prizes", draw.PoolHandler)
 		}
+		// mission api group
+		missionGroup := apiV1.Group("/missions")
+		{
+			missionGroup.GET("", mission.ListHandler)
+			missionGroup.POST("/:code/claim", mission.ClaimHandler)
+		}
+
+		// admin api group，只允许配置文件中的管理员访问
+		adminGroup := apiV1.Group("/admin", middleware.Admin())
+		{
+			adminGroup.GET("/campaigns", campaign.ListHandler)
+			adminGroup.POST("/campaigns", campaign.CreateHandler)
+			adminGroup.GET("/campaigns/:id", campaign.GetHandler)
+			adminGroup.PUT("/campaigns/:id", campaign.UpdateHandler)
+			adminGroup.DELETE("/campaigns/:id", campaign.DeleteHandler)
+		}
+	}
+
+	// 内部接口，供其它服务调用，不经过用户认证
+	// 使用 API key 签名认证，key 需要有对应的接口范围，只能访问 key 所属租户的数据
+	internalV1 := r.Group("/internal/v1")
+	{
+		internalV1.POST("/missions/events", middleware.APIKey(model.APIScopeMissionEvent), mission.ReportEventHandler)       // 上报任务事件
+		internalV1.POST("/points/credits", middleware.APIKey(model.APIScopePointsCredit), points.CreditHandler)              // 给用户发放积分
+		internalV1.GET("/users/:userId/checkin-status", middleware.APIKey(model.APIScopeCheckinRead), checkin.StatusHandler) // 查询用户的签到状态
 	}
 
 	r.NoRoute(func(c *gin.Context) {
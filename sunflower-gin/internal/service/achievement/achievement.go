@@ -0,0 +1,275 @@
+package achievement
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/metrics"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+	"sunflower-gin/pkg/tracing"
+
+	"go.uber.org/zap"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 成就徽章
+// 成就的定义在代码中，签到、补签和积分变动后按事件类型重新计算相关成就的进度，达到目标值时获得成就
+// 获得成就后在用户查看通知之前，都会出现在通知列表中
+
+// 成就的统计指标
+type metric int
+
+const (
+	metricTotalCheckins  metric = iota + 1 // 累计每日签到次数，不含补签
+	metricStreakDays                       // 最长连续签到天数，包含补签
+	metricRedemptions                      // 消耗积分兑换的次数，目前只有补签
+	metricLifetimePoints                   // 累计获得的积分
+)
+
+// definition 成就的定义，名称和获得条件的多语言 key 为 achievement.{code}.name 和 achievement.{code}.desc
+type definition struct {
+	Code   string
+	Metric metric
+	Target int64
+}
+
+// definitions 所有成就，按展示顺序排列，已经上线的成就不能修改 Code
+var definitions = []*definition{
+	{Code: "first_checkin", Metric: metricTotalCheckins, Target: 1},   // 第一次签到
+	{Code: "checkins_100", Metric: metricTotalCheckins, Target: 100},  // 累计签到100次
+	{Code: "streak_30", Metric: metricStreakDays, Target: 30},         // 连续签到30天
+	{Code: "first_redemption", Metric: metricRedemptions, Target: 1},  // 第一次用积分兑换
+	{Code: "points_1000", Metric: metricLifetimePoints, Target: 1000}, // 累计获得1000积分
+}
+
+// eventMetrics 每种事件会影响的指标
+var eventMetrics = map[model.AchievementEventType][]metric{
+	model.AchievementEventCheckin:      {metricTotalCheckins, metricStreakDays, metricLifetimePoints},
+	model.AchievementEventRetroactive:  {metricStreakDays, metricRedemptions},
+	model.AchievementEventPointsEarned: {metricLifetimePoints},
+}
+
+// Evaluate 根据事件重新计算用户相关成就的进度，返回这次新获得的成就编码
+// 在积分变动的事务提交之后调用，计算失败不影响原来的业务
+func Evaluate(ctx context.Context, event *model.AchievementEvent) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "achievement.Evaluate")
+	defer span.End()
+	affected := eventMetrics[event.Type]
+	var defs []*definition
+	var codes []string
+	for _, def := range definitions {
+		if slices.Contains(affected, def.Metric) {
+			defs = append(defs, def)
+			codes = append(codes, def.Code)
+		}
+	}
+	if len(defs) == 0 {
+		return nil, nil
+	}
+	// 1. 查询用户已有的进度，走主库避免重复计算刚获得的成就
+	ua := query.UserAchievement
+	rows, err := ua.WithContext(ctx).WriteDB().Where(ua.UserID.Eq(event.UserID), ua.Code.In(codes...)).Find()
+	if err != nil {
+		logging.Ctx(ctx).Error("query user_achievements error", zap.Error(err))
+		return nil, err
+	}
+	rowMap := make(map[string]*model.UserAchievement, len(rows))
+	for _, v := range rows {
+		rowMap[v.Code] = v
+	}
+	// 2. 计算指标，同一个指标只计算一次
+	values := make(map[metric]int64)
+	var unlocked []string
+	now := time.Now()
+	for _, def := range defs {
+		row := rowMap[def.Code]
+		if row != nil && row.UnlockedAt != nil {
+			continue
+		}
+		value, ok := values[def.Metric]
+		if !ok {
+			value, err = measure(ctx, event, def.Metric)
+			if err != nil {
+				return unlocked, err
+			}
+			values[def.Metric] = value
+		}
+		progress := min(value, def.Target)
+		if row != nil && progress <= row.Progress {
+			continue
+		}
+		// 3. 保存进度，达到目标值时获得成就
+		ok, err := save(ctx, event.UserID, def, progress, now)
+		if err != nil {
+			return unlocked, err
+		}
+		if ok && progress >= def.Target {
+			unlocked = append(unlocked, def.Code)
+			metrics.AchievementUnlockedTotal.WithLabelValues(def.Code).Inc()
+			logging.Ctx(ctx).Info("achievement unlocked", zap.Int64("user_id", event.UserID), zap.String("code", def.Code))
+		}
+	}
+	if len(unlocked) > 0 {
+		dao.MarkWritten(ctx, event.UserID)
+	}
+	return unlocked, nil
+}
+
+// measure 计算指标的当前值，连续签到天数由事件带过来，其它指标按历史数据统计
+func measure(ctx context.Context, event *model.AchievementEvent, m metric) (int64, error) {
+	switch m {
+	case metricStreakDays:
+		return int64(event.StreakDays), nil
+	case metricTotalCheckins:
+		return countTransactions(ctx, event.UserID, model.PointsTransactionTypeDaily)
+	case metricRedemptions:
+		return countTransactions(ctx, event.UserID, model.PointsTransactionTypeRetroactive)
+	case metricLifetimePoints:
+		up, err := query.UserPoint.WithContext(ctx).WriteDB().
+			Select(query.UserPoint.PointsTotal).
+			Where(query.UserPoint.UserID.Eq(event.UserID)).
+			First()
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, nil
+			}
+			logging.Ctx(ctx).Error("query user_points error", zap.Error(err))
+			return 0, err
+		}
+		return up.PointsTotal, nil
+	}
+	return 0, nil
+}
+
+// countTransactions 统计用户某种类型的积分流水数量，每次签到和补签都会生成一条流水
+func countTransactions(ctx context.Context, userID int64, t model.PointsTransactionType) (int64, error) {
+	pt := query.UserPointsTransaction
+	count, err := pt.WithContext(ctx).WriteDB().
+		Where(pt.UserID.Eq(userID), pt.TransactionType.Eq(int32(t))).
+		Count()
+	if err != nil {
+		logging.Ctx(ctx).Error("count user_points_transactions error", zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+// save 保存成就进度，进度只增不减，已经获得的成就不再修改，并发更新时只有一个会成功
+func save(ctx context.Context, userID int64, def *definition, progress int64, now time.Time) (bool, error) {
+	row := &model.UserAchievement{UserID: userID, Code: def.Code, Progress: progress}
+	if progress >= def.Target {
+		row.UnlockedAt = &now
+	}
+	ua := query.UserAchievement
+	res := ua.WithContext(ctx).UnderlyingDB().Clauses(clause.OnConflict{DoNothing: true}).Create(row)
+	if res.Error != nil {
+		logging.Ctx(ctx).Error("create user_achievements error", zap.Error(res.Error))
+		return false, res.Error
+	}
+	if res.RowsAffected > 0 {
+		return true, nil
+	}
+	// 已经有进度记录
+	updates := []field.AssignExpr{ua.Progress.Value(progress)}
+	if row.UnlockedAt != nil {
+		updates = append(updates, ua.UnlockedAt.Value(now))
+	}
+	info, err := ua.WithContext(ctx).
+		Where(ua.UserID.Eq(userID), ua.Code.Eq(def.Code), ua.UnlockedAt.IsNull(), ua.Progress.Lt(progress)).
+		UpdateSimple(updates...)
+	if err != nil {
+		logging.Ctx(ctx).Error("update user_achievements error", zap.Error(err))
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+// List 所有成就和用户的进度，按定义的顺序排列
+func List(ctx context.Context, userID int64) ([]*model.AchievementInfo, error) {
+	ua := query.UserAchievement
+	do := ua.WithContext(ctx)
+	if dao.ReadPrimary(ctx, userID) {
+		do = do.WriteDB()
+	}
+	rows, err := do.Where(ua.UserID.Eq(userID)).Find()
+	if err != nil {
+		logging.Ctx(ctx).Error("query user_achievements error", zap.Error(err))
+		return nil, err
+	}
+	rowMap := make(map[string]*model.UserAchievement, len(rows))
+	for _, v := range rows {
+		rowMap[v.Code] = v
+	}
+	lang := i18n.FromContext(ctx)
+	list := make([]*model.AchievementInfo, 0, len(definitions))
+	for _, def := range definitions {
+		info := toInfo(lang, def)
+		if row, ok := rowMap[def.Code]; ok {
+			info.Progress = row.Progress
+			info.UnlockedAt = row.UnlockedAt
+		}
+		list = append(list, info)
+	}
+	return list, nil
+}
+
+// Notifications 已经获得但用户还没有查看的成就，按获得时间排列
+func Notifications(ctx context.Context, userID int64) ([]*model.AchievementInfo, error) {
+	ua := query.UserAchievement
+	do := ua.WithContext(ctx)
+	if dao.ReadPrimary(ctx, userID) {
+		do = do.WriteDB()
+	}
+	rows, err := do.
+		Where(ua.UserID.Eq(userID), ua.UnlockedAt.IsNotNull(), ua.NotifiedAt.IsNull()).
+		Order(ua.UnlockedAt).
+		Find()
+	if err != nil {
+		logging.Ctx(ctx).Error("query user_achievements error", zap.Error(err))
+		return nil, err
+	}
+	lang := i18n.FromContext(ctx)
+	list := make([]*model.AchievementInfo, 0, len(rows))
+	for _, row := range rows {
+		idx := slices.IndexFunc(definitions, func(def *definition) bool { return def.Code == row.Code })
+		if idx < 0 {
+			continue // 已经下线的成就
+		}
+		info := toInfo(lang, definitions[idx])
+		info.Progress = row.Progress
+		info.UnlockedAt = row.UnlockedAt
+		list = append(list, info)
+	}
+	return list, nil
+}
+
+// Ack 标记成就通知为已查看
+func Ack(ctx context.Context, userID int64, codes []string) error {
+	ua := query.UserAchievement
+	_, err := ua.WithContext(ctx).
+		Where(ua.UserID.Eq(userID), ua.Code.In(codes...), ua.UnlockedAt.IsNotNull(), ua.NotifiedAt.IsNull()).
+		UpdateSimple(ua.NotifiedAt.Value(time.Now()))
+	if err != nil {
+		logging.Ctx(ctx).Error("update user_achievements error", zap.Error(err))
+		return err
+	}
+	dao.MarkWritten(ctx, userID)
+	return nil
+}
+
+func toInfo(lang string, def *definition) *model.AchievementInfo {
+	return &model.AchievementInfo{
+		Code:        def.Code,
+		Name:        i18n.T(lang, "achievement."+def.Code+".name"),
+		Description: i18n.T(lang, "achievement."+def.Code+".desc"),
+		Target:      def.Target,
+	}
+}
@@ -0,0 +1,156 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 服务端 API key
+// 其它后端服务调用内部接口时使用，每个 key 属于一个租户，只能调用 scopes 中的接口，由 cmd/admin 创建和吊销
+
+const (
+	keyIDPrefix  = "ak_"
+	keyIDBytes   = 12 // key_id 随机部分的字节数
+	secretBytes  = 32 // secret 的字节数
+	scopesSep    = ","
+	touchMinimum = time.Minute // last_used_at 最多每分钟更新一次，避免每个请求都写库
+)
+
+var (
+	ErrNotFound     = i18n.NewError("error.apikey.not_found")     // API key 不存在或已经吊销
+	ErrInvalidScope = i18n.NewError("error.apikey.invalid_scope") // 不支持的接口范围
+)
+
+// Create 创建 API key，secret 只在这里返回一次
+func Create(ctx context.Context, input *model.APIKeyCreateInput) (*model.APIKeyCreateOutput, error) {
+	var scopes []string
+	for _, s := range input.Scopes {
+		if !slices.Contains(model.APIScopes, s) {
+			return nil, ErrInvalidScope
+		}
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, ErrInvalidScope
+	}
+	keyID, err := randomHex(keyIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(secretBytes)
+	if err != nil {
+		return nil, err
+	}
+	row := &model.APIKey{
+		KeyID:  keyIDPrefix + keyID,
+		Secret: secret,
+		Name:   input.Name,
+		Scopes: strings.Join(scopes, scopesSep),
+	}
+	if err := query.APIKey.WithContext(ctx).Create(row); err != nil {
+		logging.Ctx(ctx).Error("create api_keys error", zap.Error(err))
+		return nil, err
+	}
+	return &model.APIKeyCreateOutput{KeyID: row.KeyID, Secret: row.Secret}, nil
+}
+
+// List 当前租户的所有 API key，包含已经吊销的
+func List(ctx context.Context) ([]*model.APIKeyInfo, error) {
+	ak := query.APIKey
+	rows, err := ak.WithContext(ctx).Order(ak.ID).Find()
+	if err != nil {
+		logging.Ctx(ctx).Error("query api_keys error", zap.Error(err))
+		return nil, err
+	}
+	list := make([]*model.APIKeyInfo, 0, len(rows))
+	for _, row := range rows {
+		list = append(list, toInfo(row))
+	}
+	return list, nil
+}
+
+// Revoke 吊销 API key，吊销后立即不能再调用
+func Revoke(ctx context.Context, keyID string) error {
+	ak := query.APIKey
+	info, err := ak.WithContext(ctx).
+		Where(ak.KeyID.Eq(keyID), ak.RevokedAt.IsNull()).
+		UpdateSimple(ak.RevokedAt.Value(time.Now()))
+	if err != nil {
+		logging.Ctx(ctx).Error("update api_keys error", zap.Error(err))
+		return err
+	}
+	if info.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// get 按 key_id 查询未吊销的 API key
+func get(ctx context.Context, keyID string) (*model.APIKey, error) {
+	ak := query.APIKey
+	row, err := ak.WithContext(ctx).Where(ak.KeyID.Eq(keyID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		logging.Ctx(ctx).Error("query api_keys error", zap.Error(err))
+		return nil, err
+	}
+	if row.RevokedAt != nil {
+		return nil, ErrNotFound
+	}
+	return row, nil
+}
+
+// touch 记录最后一次调用的时间，失败不影响请求
+func touch(ctx context.Context, row *model.APIKey, now time.Time) {
+	if row.LastUsedAt != nil && now.Sub(*row.LastUsedAt) < touchMinimum {
+		return
+	}
+	ak := query.APIKey
+	if _, err := ak.WithContext(ctx).Where(ak.ID.Eq(row.ID)).UpdateSimple(ak.LastUsedAt.Value(now)); err != nil {
+		logging.Ctx(ctx).Warn("update api_keys last_used_at error", zap.Error(err))
+	}
+}
+
+func toInfo(row *model.APIKey) *model.APIKeyInfo {
+	return &model.APIKeyInfo{
+		ID:         row.ID,
+		KeyID:      row.KeyID,
+		Name:       row.Name,
+		Scopes:     parseScopes(row.Scopes),
+		LastUsedAt: row.LastUsedAt,
+		RevokedAt:  row.RevokedAt,
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
+func parseScopes(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, scopesSep)
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,139 @@
+package apikey
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/metrics"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/tenant"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+
+	"go.uber.org/zap"
+)
+
+// 签名认证
+// 签名内容为 "{method}\n{path}\n{timestamp}\n{nonce}\n{hex(sha256(body))}"，用 secret 计算 HMAC-SHA256 后 hex 编码
+// 时间戳与服务器时间的误差不能超过 internal.signature_ttl，同一个 key 的 nonce 在 2 倍时长内不能重复，防止请求被重放
+
+const (
+	nonceKeyFormat = "apikey:nonce:%s:%s" // apikey:nonce:ak_xxx:{nonce}
+	maxNonceLen    = 64
+)
+
+var (
+	ErrInvalidSignature = i18n.NewError("error.apikey.invalid_signature") // 签名错误
+	ErrExpired          = i18n.NewError("error.apikey.expired")           // 请求时间戳超出允许的误差
+	ErrReplayed         = i18n.NewError("error.apikey.replayed")          // nonce 已经使用过
+	ErrScopeDenied      = i18n.NewError("error.apikey.scope_denied")      // 没有调用这个接口的权限
+)
+
+// Sign 计算请求的签名，调用方和服务端使用相同的算法
+func Sign(secret, method, path, timestamp, nonce string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n%s", method, path, timestamp, nonce, hex.EncodeToString(bodyHash[:]))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 校验签名请求，通过时返回调用方的 API key
+// 依次校验时间戳、key、签名和接口范围，都通过后才占用 nonce，签名错误的请求不影响调用方重试
+func Verify(ctx context.Context, input *model.APIKeyVerifyInput) (*model.APIKeyInfo, error) {
+	row, err := verify(ctx, input)
+	if err != nil {
+		var result string
+		switch {
+		case errors.Is(err, ErrNotFound):
+			result = "invalid_key"
+		case errors.Is(err, ErrInvalidSignature):
+			result = "invalid_signature"
+		case errors.Is(err, ErrExpired):
+			result = "expired"
+		case errors.Is(err, ErrReplayed):
+			result = "replayed"
+		case errors.Is(err, ErrScopeDenied):
+			result = "scope_denied"
+		default:
+			result = metrics.StatusError
+		}
+		metrics.APIKeyAuthTotal.WithLabelValues(result).Inc()
+		return nil, err
+	}
+	metrics.APIKeyAuthTotal.WithLabelValues(metrics.StatusOK).Inc()
+	touch(ctx, row, time.Now())
+	return toInfo(row), nil
+}
+
+func verify(ctx context.Context, input *model.APIKeyVerifyInput) (*model.APIKey, error) {
+	// 1. 校验请求头和时间戳，不需要查询 key
+	ttl := conf.Get().Internal.SignatureTTL
+	if err := checkRequest(input, time.Now(), ttl); err != nil {
+		return nil, err
+	}
+	// 2. 查询 key，只能查到当前租户的 key
+	row, err := get(ctx, input.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	// 3. 校验签名、接口范围和 nonce
+	if err := checkKey(ctx, row, input, ttl); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// checkRequest 校验签名请求头是否完整，时间戳与 now 的误差不能超过 ttl
+func checkRequest(input *model.APIKeyVerifyInput, now time.Time, ttl time.Duration) error {
+	if input.KeyID == "" || input.Nonce == "" || len(input.Nonce) > maxNonceLen || input.Signature == "" {
+		return ErrInvalidSignature
+	}
+	ts, err := strconv.ParseInt(input.Timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if d := now.Sub(time.Unix(ts, 0)); d > ttl || d < -ttl {
+		return ErrExpired
+	}
+	return nil
+}
+
+// checkKey 用 key 的 secret 校验签名，再校验接口范围，都通过后占用 nonce
+func checkKey(ctx context.Context, row *model.APIKey, input *model.APIKeyVerifyInput, ttl time.Duration) error {
+	got, err := hex.DecodeString(input.Signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	want, _ := hex.DecodeString(Sign(row.Secret, input.Method, input.Path, input.Timestamp, input.Nonce, input.Body))
+	if !hmac.Equal(got, want) {
+		return ErrInvalidSignature
+	}
+	if !slices.Contains(parseScopes(row.Scopes), input.Scope) {
+		return ErrScopeDenied
+	}
+	// nonce 的有效期覆盖时间戳前后允许的误差
+	key := tenant.Key(ctx, nonceKey(row.KeyID, input.Nonce))
+	ok, err := dao.RedisClient.SetNX(ctx, key, 1, 2*ttl).Result()
+	if err != nil {
+		logging.Ctx(ctx).Error("setnx api key nonce error", zap.Error(err))
+		return err
+	}
+	if !ok {
+		return ErrReplayed
+	}
+	return nil
+}
+
+// nonceKey 记录已经使用过的 nonce 的 Redis key，不含租户前缀
+func nonceKey(keyID, nonce string) string {
+	return fmt.Sprintf(nonceKeyFormat, keyID, nonce)
+}
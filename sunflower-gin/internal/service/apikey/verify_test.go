@@ -0,0 +1,114 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/model"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	testSecret = "s3cr3t"
+	testTTL    = 5 * time.Minute
+)
+
+// signedInput 用 testSecret 签名的请求，modify 在签名之后修改请求，模拟被篡改或参数错误的请求
+func signedInput(now time.Time, nonce string, modify func(*model.APIKeyVerifyInput)) *model.APIKeyVerifyInput {
+	input := &model.APIKeyVerifyInput{
+		KeyID:     "ak_test",
+		Timestamp: strconv.FormatInt(now.Unix(), 10),
+		Nonce:     nonce,
+		Method:    "POST",
+		Path:      "/internal/v1/points/credits",
+		Body:      []byte(`{"userId":1,"points":10}`),
+		Scope:     model.APIScopePointsCredit,
+	}
+	input.Signature = Sign(testSecret, input.Method, input.Path, input.Timestamp, input.Nonce, input.Body)
+	if modify != nil {
+		modify(input)
+	}
+	return input
+}
+
+func TestCheckRequest(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		modify func(*model.APIKeyVerifyInput)
+		want   error
+	}{
+		{"ok", nil, nil},
+		{"missing key", func(in *model.APIKeyVerifyInput) { in.KeyID = "" }, ErrInvalidSignature},
+		{"missing nonce", func(in *model.APIKeyVerifyInput) { in.Nonce = "" }, ErrInvalidSignature},
+		{"nonce too long", func(in *model.APIKeyVerifyInput) { in.Nonce = string(make([]byte, maxNonceLen+1)) }, ErrInvalidSignature},
+		{"missing signature", func(in *model.APIKeyVerifyInput) { in.Signature = "" }, ErrInvalidSignature},
+		{"timestamp not a number", func(in *model.APIKeyVerifyInput) { in.Timestamp = "abc" }, ErrInvalidSignature},
+		{"timestamp at ttl", func(in *model.APIKeyVerifyInput) {
+			in.Timestamp = strconv.FormatInt(now.Add(-testTTL).Unix(), 10)
+		}, nil},
+		{"timestamp too old", func(in *model.APIKeyVerifyInput) {
+			in.Timestamp = strconv.FormatInt(now.Add(-testTTL-time.Second).Unix(), 10)
+		}, ErrExpired},
+		{"timestamp too far in future", func(in *model.APIKeyVerifyInput) {
+			in.Timestamp = strconv.FormatInt(now.Add(testTTL+time.Second).Unix(), 10)
+		}, ErrExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 时间戳只精确到秒，校验时用整秒的 now，避免边界用例受亚秒误差影响
+			err := checkRequest(signedInput(now, "n1", tt.modify), now.Truncate(time.Second), testTTL)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("checkRequest() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckKey(t *testing.T) {
+	mr := miniredis.RunT(t)
+	dao.RedisClient = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { dao.RedisClient.Close() })
+
+	ctx := context.Background()
+	now := time.Now()
+	row := &model.APIKey{KeyID: "ak_test", Secret: testSecret, Scopes: model.APIScopePointsCredit + "," + model.APIScopeCheckinRead}
+	tests := []struct {
+		name   string
+		nonce  string
+		modify func(*model.APIKeyVerifyInput)
+		want   error
+	}{
+		{"ok", "n1", nil, nil},
+		{"replayed nonce", "n1", nil, ErrReplayed},
+		{"new nonce", "n2", nil, nil},
+		{"wrong secret", "n3", func(in *model.APIKeyVerifyInput) {
+			in.Signature = Sign("other", in.Method, in.Path, in.Timestamp, in.Nonce, in.Body)
+		}, ErrInvalidSignature},
+		{"tampered body", "n4", func(in *model.APIKeyVerifyInput) { in.Body = []byte(`{"userId":1,"points":1000}`) }, ErrInvalidSignature},
+		{"tampered path", "n5", func(in *model.APIKeyVerifyInput) { in.Path += "?userId=2" }, ErrInvalidSignature},
+		{"tampered method", "n6", func(in *model.APIKeyVerifyInput) { in.Method = "PUT" }, ErrInvalidSignature},
+		{"signature not hex", "n7", func(in *model.APIKeyVerifyInput) { in.Signature = "zz" }, ErrInvalidSignature},
+		{"scope denied", "n8", func(in *model.APIKeyVerifyInput) { in.Scope = model.APIScopeMissionEvent }, ErrScopeDenied},
+		// 签名错误和没有权限的请求不占用 nonce，调用方修正后可以用同一个 nonce 重试
+		{"nonce kept after bad signature", "n3", nil, nil},
+		{"nonce kept after scope denied", "n8", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkKey(ctx, row, signedInput(now, tt.nonce, tt.modify), testTTL)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("checkKey() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if ttl := mr.TTL(nonceKey("ak_test", "n1")); ttl != 2*testTTL {
+		t.Errorf("nonce ttl = %v, want %v", ttl, 2*testTTL)
+	}
+}
@@ -2,10 +2,12 @@ package auth
 
 import (
 	"context"
-	"errors"
 	"sunflower-gin/internal/dao/query"
 	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/tenant"
+	"sunflower-gin/pkg/i18n"
 	"sunflower-gin/pkg/jwt"
+	"sunflower-gin/pkg/logging"
 
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
@@ -13,6 +15,12 @@ import (
 
 // 认证相关接口
 
+var (
+	ErrInvalidPassword = i18n.NewError("error.auth.invalid_password")  // 用户名或密码错误
+	ErrGenAccessToken  = i18n.NewError("error.auth.gen_access_token")  // 生成accessToken失败
+	ErrGenRefreshToken = i18n.NewError("error.auth.gen_refresh_token") // 生成refreshToken失败
+)
+
 // Login 登录接口
 func Login(ctx context.Context, input *model.LoginInput) (*model.LoginOutput, error) {
 	// 1. 登录校验
@@ -20,31 +28,32 @@ func Login(ctx context.Context, input *model.LoginInput) (*model.LoginOutput, er
 	// Where(query.Userinfo.Username.Eq(input.Username)).
 	// Where(query.Userinfo.Password.Eq(input.Password)).
 	// Find()
-	userInst, err := query.Userinfo.WithContext(ctx).
+	// 注册后立即登录时从库可能还没有同步到新用户，登录查询走主库
+	userInst, err := query.Userinfo.WithContext(ctx).WriteDB().
 		Where(query.Userinfo.Username.Eq(input.Username)).
 		First()
 	if err != nil {
-		zap.L().Error("Login: query user failed", zap.Error(err))
-		return nil, errors.New("用户名或密码错误")
+		logging.Ctx(ctx).Error("Login: query user failed", zap.Error(err))
+		return nil, ErrInvalidPassword
 	}
 	// userInst.Password  // 加密之后的 password
 	if err := bcrypt.CompareHashAndPassword(
 		[]byte(userInst.Password), []byte(input.Password)); err != nil {
-		zap.L().Error("Login: password compare failed", zap.Error(err))
-		return nil, errors.New("用户名或密码错误")
+		logging.Ctx(ctx).Error("Login: password compare failed", zap.Error(err))
+		return nil, ErrInvalidPassword
 	}
 	// 2. 如果登录成功，生成token
 	// 2.1 生成access token
-	accessToken, err := jwt.GenAccessToken(userInst.UserID, userInst.Username)
+	accessToken, err := jwt.GenAccessToken(userInst.UserID, userInst.Username, tenant.Issuer(ctx))
 	if err != nil {
-		zap.L().Error("Login: generate access token failed", zap.Error(err))
-		return nil, errors.New("生成accessToken失败")
+		logging.Ctx(ctx).Error("Login: generate access token failed", zap.Error(err))
+		return nil, ErrGenAccessToken
 	}
 	// 2.2 生成refresh token
-	refreshToken, err := jwt.GenRefreshToken(userInst.UserID, userInst.Username)
+	refreshToken, err := jwt.GenRefreshToken(userInst.UserID, userInst.Username, tenant.Issuer(ctx))
 	if err != nil {
-		zap.L().Error("Login: generate refresh token failed", zap.Error(err))
-		return nil, errors.New("生成refreshToken失败")
+		logging.Ctx(ctx).Error("Login: generate refresh token failed", zap.Error(err))
+		return nil, ErrGenRefreshToken
 	}
 	// 3. 返回token
 	return &model.LoginOutput{
@@ -4,16 +4,18 @@ import (
 	"context"
 	"sunflower-gin/internal/dao/query"
 	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/tenant"
 	"sunflower-gin/pkg/jwt"
+	"sunflower-gin/pkg/logging"
 
 	"go.uber.org/zap"
 )
 
 func RefreshToken(ctx context.Context, token string) (*model.RefreshTokenOutput, error) {
 	// 1. 校验refreshToken是否有效
-	claims, err := jwt.ParseRefreshToken(token)
+	claims, err := jwt.ParseRefreshToken(token, tenant.Issuer(ctx))
 	if err != nil {
-		zap.L().Error("refreshToken校验失败", zap.Error(err))
+		logging.Ctx(ctx).Error("refreshToken校验失败", zap.Error(err))
 		return nil, err
 	}
 	// 2. 解析得到userID
@@ -23,18 +25,18 @@ func RefreshToken(ctx context.Context, token string) (*model.RefreshTokenOutput,
 		Where(query.Userinfo.UserID.Eq(userId)).
 		First()
 	if err != nil {
-		zap.L().Error("根据userID查询用户信息失败", zap.Error(err))
+		logging.Ctx(ctx).Error("根据userID查询用户信息失败", zap.Error(err))
 		return nil, err
 	}
 	// 4. 生成新的accessToken和refreshToken
-	accessToken, err := jwt.GenAccessToken(userInst.UserID, userInst.Username)
+	accessToken, err := jwt.GenAccessToken(userInst.UserID, userInst.Username, tenant.Issuer(ctx))
 	if err != nil {
-		zap.L().Error("生成新的accessToken失败", zap.Error(err))
+		logging.Ctx(ctx).Error("生成新的accessToken失败", zap.Error(err))
 		return nil, err
 	}
-	refreshToken, err := jwt.GenRefreshToken(userInst.UserID, userInst.Username)
+	refreshToken, err := jwt.GenRefreshToken(userInst.UserID, userInst.Username, tenant.Issuer(ctx))
 	if err != nil {
-		zap.L().Error("生成新的refreshToken失败", zap.Error(err))
+		logging.Ctx(ctx).Error("生成新的refreshToken失败", zap.Error(err))
 		return nil, err
 	}
 	// 5. 返回新的token
@@ -0,0 +1,287 @@
+package campaign
+
+import (
+	"context"
+	"errors"
+
+	"sunflower-gin/internal/cache"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/ledger"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+	"sunflower-gin/pkg/tracing"
+
+	"go.uber.org/zap"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 营销活动
+// 倍率活动在活动期间按倍率多发每日签到积分，目标活动在活动期间签到满指定天数后一次性发放奖励
+// 设置了预算的活动创建时从系统发放账户转入活动奖池，活动奖励从奖池转出，奖池余额不足时不再发放；删除活动时奖池余额退回系统发放账户
+// 没有设置预算的活动和签到积分一样直接从系统发放账户转出
+
+const (
+	fundingDescKey = "points.desc.campaign_funding" // 活动预算：%s
+	refundDescKey  = "points.desc.campaign_refund"  // 活动预算退回：%s
+)
+
+var (
+	ErrNotFound          = i18n.NewError("error.campaign.not_found")          // 活动不存在
+	ErrInvalidTime       = i18n.NewError("error.campaign.invalid_time")       // 结束时间必须晚于开始时间
+	ErrInvalidMultiplier = i18n.NewError("error.campaign.invalid_multiplier") // 倍率活动需要设置大于 100 的倍率
+	ErrInvalidGoal       = i18n.NewError("error.campaign.invalid_goal")       // 目标活动需要设置签到天数和奖励积分
+	ErrTypeImmutable     = i18n.NewError("error.campaign.type_immutable")     // 活动类型不能修改
+	ErrBudgetDecrease    = i18n.NewError("error.campaign.budget_decrease")    // 活动预算只能增加
+)
+
+// Create 创建活动，设置了预算时在同一个事务中把预算转入活动奖池
+func Create(ctx context.Context, input *model.CampaignInput) (*model.CampaignInfo, error) {
+	ctx, span := tracing.Start(ctx, "campaign.Create")
+	defer span.End()
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+	rulesJSON, err := input.Rules.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	c := &model.Campaign{
+		Name:        input.Name,
+		Type:        int32(input.Type),
+		Status:      int32(input.Status),
+		StartAt:     input.StartAt,
+		EndAt:       input.EndAt,
+		Multiplier:  input.Multiplier,
+		GoalDays:    input.GoalDays,
+		BonusPoints: input.BonusPoints,
+		Budget:      input.Budget,
+		RulesJSON:   rulesJSON,
+	}
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if err := tx.Campaign.WithContext(ctx).Create(c); err != nil {
+			logging.Ctx(ctx).Error("create campaigns error", zap.Error(err))
+			return err
+		}
+		return moveBudget(ctx, tx, c, c.Budget)
+	})
+	if err != nil {
+		return nil, err
+	}
+	cache.Delete(ctx, cache.ActiveCampaignsKey(ctx))
+	return toInfo(c, c.Budget), nil
+}
+
+// Update 修改活动，活动类型不能修改，预算只能增加，增加的部分转入活动奖池
+func Update(ctx context.Context, input *model.CampaignInput) (*model.CampaignInfo, error) {
+	ctx, span := tracing.Start(ctx, "campaign.Update")
+	defer span.End()
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+	rulesJSON, err := input.Rules.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	var c *model.Campaign
+	var remaining int64
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		// 1. 加锁读取活动，避免并发修改预算重复划转
+		c, err = lockCampaign(ctx, tx, input.ID)
+		if err != nil {
+			return err
+		}
+		if c.Type != int32(input.Type) {
+			return ErrTypeImmutable
+		}
+		if input.Budget < c.Budget {
+			return ErrBudgetDecrease
+		}
+		added := input.Budget - c.Budget
+		// 2. 更新活动
+		c.Name = input.Name
+		c.Status = int32(input.Status)
+		c.StartAt = input.StartAt
+		c.EndAt = input.EndAt
+		c.Multiplier = input.Multiplier
+		c.GoalDays = input.GoalDays
+		c.BonusPoints = input.BonusPoints
+		c.Budget = input.Budget
+		c.RulesJSON = rulesJSON
+		if err := tx.Campaign.WithContext(ctx).Save(c); err != nil {
+			logging.Ctx(ctx).Error("update campaigns error", zap.Int64("campaign_id", c.ID), zap.Error(err))
+			return err
+		}
+		// 3. 增加的预算转入活动奖池
+		if err := moveBudget(ctx, tx, c, added); err != nil {
+			return err
+		}
+		remaining, err = ledger.Balance(ctx, tx, model.LedgerAccountTypeCampaignPool, c.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	cache.Delete(ctx, cache.ActiveCampaignsKey(ctx))
+	return toInfo(c, remaining), nil
+}
+
+// Delete 删除活动，活动奖池的余额退回系统发放账户，用户已经获得的奖励不受影响
+func Delete(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "campaign.Delete")
+	defer span.End()
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		c, err := lockCampaign(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		remaining, err := ledger.Balance(ctx, tx, model.LedgerAccountTypeCampaignPool, c.ID)
+		if err != nil {
+			return err
+		}
+		if err := moveBudget(ctx, tx, c, -remaining); err != nil {
+			return err
+		}
+		if _, err := tx.Campaign.WithContext(ctx).Where(tx.Campaign.ID.Eq(c.ID)).Delete(); err != nil {
+			logging.Ctx(ctx).Error("delete campaigns error", zap.Int64("campaign_id", c.ID), zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	cache.Delete(ctx, cache.ActiveCampaignsKey(ctx))
+	return nil
+}
+
+// Get 查询活动详情，管理接口的查询走主库，修改之后立即可以看到
+func Get(ctx context.Context, id int64) (*model.CampaignInfo, error) {
+	c, err := query.Campaign.WithContext(ctx).WriteDB().Where(query.Campaign.ID.Eq(id)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logging.Ctx(ctx).Error("query campaigns error", zap.Int64("campaign_id", id), zap.Error(err))
+		return nil, err
+	}
+	balances, err := ledger.Balances(ctx, model.LedgerAccountTypeCampaignPool, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toInfo(c, balances[c.ID]), nil
+}
+
+// List 分页查询活动，按创建时间倒序
+func List(ctx context.Context, input *model.CampaignListInput) (*model.CampaignListOutput, error) {
+	c := query.Campaign
+	var conds []gen.Condition
+	if input.Status != 0 {
+		conds = append(conds, c.Status.Eq(int32(input.Status)))
+	}
+	list, total, err := c.WithContext(ctx).WriteDB().
+		Where(conds...).
+		Order(c.ID.Desc()).
+		FindByPage(input.Offset, input.Limit)
+	if err != nil {
+		logging.Ctx(ctx).Error("query campaigns error", zap.Error(err))
+		return nil, err
+	}
+	ids := make([]int64, 0, len(list))
+	for _, v := range list {
+		ids = append(ids, v.ID)
+	}
+	balances, err := ledger.Balances(ctx, model.LedgerAccountTypeCampaignPool, ids...)
+	if err != nil {
+		return nil, err
+	}
+	output := &model.CampaignListOutput{Total: total, List: make([]*model.CampaignInfo, 0, len(list))}
+	for _, v := range list {
+		output.List = append(output.List, toInfo(v, balances[v.ID]))
+	}
+	return output, nil
+}
+
+// validate 校验不同类型的活动需要的参数
+func validate(input *model.CampaignInput) error {
+	if !input.EndAt.After(input.StartAt) {
+		return ErrInvalidTime
+	}
+	switch input.Type {
+	case model.CampaignTypeMultiplier:
+		if input.Multiplier <= 100 {
+			return ErrInvalidMultiplier
+		}
+	case model.CampaignTypeCheckinGoal:
+		if input.GoalDays <= 0 || input.BonusPoints <= 0 {
+			return ErrInvalidGoal
+		}
+	}
+	return nil
+}
+
+// lockCampaign 在事务中加锁读取活动
+func lockCampaign(ctx context.Context, tx *query.Query, id int64) (*model.Campaign, error) {
+	c, err := tx.Campaign.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(tx.Campaign.ID.Eq(id)).
+		First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logging.Ctx(ctx).Error("lock campaigns error", zap.Int64("campaign_id", id), zap.Error(err))
+		return nil, err
+	}
+	return c, nil
+}
+
+// moveBudget 在系统发放账户和活动奖池之间划转预算，amount 为正数时转入奖池，为负数时退回系统发放账户
+func moveBudget(ctx context.Context, tx *query.Query, c *model.Campaign, amount int64) error {
+	if amount == 0 {
+		return nil
+	}
+	descKey := fundingDescKey
+	if amount < 0 {
+		descKey = refundDescKey
+	}
+	_, err := ledger.Post(ctx, tx, &model.LedgerEntryInput{
+		Type:     model.PointsTransactionTypeFunding,
+		DescKey:  descKey,
+		DescArgs: []any{c.Name},
+		Ext:      model.TransactionExt{CampaignID: c.ID},
+		Lines: []*model.LedgerLine{
+			{AccountType: model.LedgerAccountTypeIssuance, Amount: -amount},
+			{AccountType: model.LedgerAccountTypeCampaignPool, OwnerID: c.ID, Amount: amount},
+		},
+	})
+	if err != nil {
+		logging.Ctx(ctx).Error("move campaign budget error", zap.Int64("campaign_id", c.ID), zap.Int64("amount", amount), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func toInfo(c *model.Campaign, remaining int64) *model.CampaignInfo {
+	info := &model.CampaignInfo{
+		ID:              c.ID,
+		Name:            c.Name,
+		Type:            model.CampaignType(c.Type),
+		Status:          model.CampaignStatus(c.Status),
+		StartAt:         c.StartAt,
+		EndAt:           c.EndAt,
+		Multiplier:      c.Multiplier,
+		GoalDays:        c.GoalDays,
+		BonusPoints:     c.BonusPoints,
+		Budget:          c.Budget,
+		RemainingBudget: remaining,
+		CreatedAt:       c.CreatedAt,
+	}
+	// 规则是服务端写入的，解析失败时按不限制展示
+	if rules, err := model.ParseCampaignRules(c.RulesJSON); err == nil {
+		info.Rules = *rules
+	}
+	return info
+}
@@ -0,0 +1,274 @@
+package campaign
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"sunflower-gin/internal/cache"
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/ledger"
+	"sunflower-gin/internal/metrics"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/dateutil"
+	"sunflower-gin/pkg/logging"
+	"sunflower-gin/pkg/tracing"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	goalDescKey = "points.desc.campaign" // 活动奖励：%s
+)
+
+// CheckinBonus 计算每日签到在倍率活动中额外获得的积分，同时有多个倍率活动时取倍率最高的一个，不叠加
+// 没有可以参与的倍率活动时返回 nil
+func CheckinBonus(ctx context.Context, userID int64, now time.Time, points int64) (*model.CampaignBonus, error) {
+	ctx, span := tracing.Start(ctx, "campaign.CheckinBonus")
+	defer span.End()
+	list, err := active(ctx)
+	if err != nil {
+		return nil, err
+	}
+	facts := &userFacts{userID: userID}
+	var best *model.Campaign
+	for _, c := range list {
+		if model.CampaignType(c.Type) != model.CampaignTypeMultiplier {
+			continue
+		}
+		if best != nil && c.Multiplier <= best.Multiplier {
+			continue
+		}
+		ok, err := applies(ctx, c, facts, now)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			best = c
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+	extra := points * int64(best.Multiplier-100) / 100
+	if extra <= 0 {
+		return nil, nil
+	}
+	return &model.CampaignBonus{
+		CampaignID:   best.ID,
+		CampaignName: best.Name,
+		Points:       extra,
+		FromPool:     best.Budget > 0,
+	}, nil
+}
+
+// OnCheckin 每日签到成功后更新用户在目标活动中的签到天数，达成目标时发放奖励
+// 每个活动单独处理，一个活动出错不影响其它活动，返回所有活动的错误
+func OnCheckin(ctx context.Context, userID int64, now time.Time) error {
+	ctx, span := tracing.Start(ctx, "campaign.OnCheckin")
+	defer span.End()
+	list, err := active(ctx)
+	if err != nil {
+		return err
+	}
+	facts := &userFacts{userID: userID}
+	var errs []error
+	for _, c := range list {
+		if model.CampaignType(c.Type) != model.CampaignTypeCheckinGoal {
+			continue
+		}
+		ok, err := applies(ctx, c, facts, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if err := advance(ctx, c, userID, now); err != nil {
+			logging.Ctx(ctx).Error("advance campaign progress error", zap.Int64("campaign_id", c.ID), zap.Error(err))
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// advance 签到天数加一，达到目标天数时发放奖励，每日签到的幂等由 Redis 的签到记录保证，同一天只会调用一次
+func advance(ctx context.Context, c *model.Campaign, userID int64, now time.Time) error {
+	awarded := false
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		// 1. 签到天数加一，第一次参与时创建进度记录
+		// gen 不允许在 OnConflict 中使用表达式，直接用 gorm
+		p := tx.CampaignUserProgress
+		err := p.WithContext(ctx).UnderlyingDB().
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: p.CampaignID.ColumnName().String()}, {Name: p.UserID.ColumnName().String()}},
+				DoUpdates: clause.Assignments(map[string]any{p.CheckinDays.ColumnName().String(): gorm.Expr("checkin_days + 1")}),
+			}).
+			Create(&model.CampaignUserProgress{CampaignID: c.ID, UserID: userID, CheckinDays: 1}).Error
+		if err != nil {
+			logging.Ctx(ctx).Error("upsert campaign_user_progress error", zap.Error(err))
+			return err
+		}
+		progress, err := p.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(p.CampaignID.Eq(c.ID), p.UserID.Eq(userID)).
+			First()
+		if err != nil {
+			logging.Ctx(ctx).Error("query campaign_user_progress error", zap.Error(err))
+			return err
+		}
+		if progress.CompletedAt != nil || progress.CheckinDays < c.GoalDays {
+			return nil
+		}
+		// 2. 达成目标，发放奖励
+		source := &model.LedgerLine{AccountType: model.LedgerAccountTypeIssuance, Amount: -c.BonusPoints}
+		if c.Budget > 0 {
+			source = &model.LedgerLine{AccountType: model.LedgerAccountTypeCampaignPool, OwnerID: c.ID, Amount: -c.BonusPoints}
+		}
+		_, err = ledger.Post(ctx, tx, &model.LedgerEntryInput{
+			Type:     model.PointsTransactionTypeCampaign,
+			DescKey:  goalDescKey,
+			DescArgs: []any{c.Name},
+			Ext:      model.TransactionExt{CampaignID: c.ID},
+			Lines: []*model.LedgerLine{
+				source,
+				{AccountType: model.LedgerAccountTypeUserWallet, OwnerID: userID, Amount: c.BonusPoints},
+			},
+		})
+		if errors.Is(err, ledger.ErrInsufficientBalance) {
+			// 奖池余额不足，保留签到天数，追加预算后下次签到时再发放
+			logging.Ctx(ctx).Warn("campaign budget exhausted", zap.Int64("campaign_id", c.ID))
+			metrics.CampaignBudgetExhaustedTotal.WithLabelValues(model.CampaignTypeCheckinGoal.String()).Inc()
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := p.WithContext(ctx).
+			Where(p.ID.Eq(progress.ID)).
+			UpdateSimple(p.CompletedAt.Value(now)); err != nil {
+			logging.Ctx(ctx).Error("update campaign_user_progress error", zap.Error(err))
+			return err
+		}
+		awarded = true
+		return nil
+	})
+	if err != nil || !awarded {
+		return err
+	}
+	dao.MarkWritten(ctx, userID)
+	cache.Delete(ctx, cache.PointsSummaryKey(userID))
+	metrics.PointsIssuedTotal.WithLabelValues(model.PointsTransactionTypeCampaign.String()).Add(float64(c.BonusPoints))
+	metrics.CampaignPointsTotal.WithLabelValues(model.CampaignTypeCheckinGoal.String()).Add(float64(c.BonusPoints))
+	return nil
+}
+
+// active 启用中并且没有结束的活动，包括还没有开始的，所有用户共用一份缓存，管理接口修改活动后删除缓存
+func active(ctx context.Context) ([]*model.Campaign, error) {
+	list, err := cache.GetOrLoad(ctx, cache.NameActiveCampaigns, cache.ActiveCampaignsKey(ctx), conf.Get().Cache.CampaignsTTL,
+		func(ctx context.Context) (*[]*model.Campaign, error) {
+			// 走主库，避免修改活动后从库延迟把旧数据写回缓存
+			c := query.Campaign
+			list, err := c.WithContext(ctx).WriteDB().
+				Where(c.Status.Eq(int32(model.CampaignStatusEnabled)), c.EndAt.Gt(time.Now())).
+				Order(c.ID).
+				Find()
+			if err != nil {
+				logging.Ctx(ctx).Error("query active campaigns error", zap.Error(err))
+				return nil, err
+			}
+			return &list, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return *list, nil
+}
+
+// applies 活动在 now 这个时间点是否对用户生效
+func applies(ctx context.Context, c *model.Campaign, facts *userFacts, now time.Time) (bool, error) {
+	if now.Before(c.StartAt) || !now.Before(c.EndAt) {
+		return false, nil
+	}
+	rules, err := model.ParseCampaignRules(c.RulesJSON)
+	if err != nil {
+		logging.Ctx(ctx).Error("parse campaign rules error", zap.Int64("campaign_id", c.ID), zap.Error(err))
+		return false, err
+	}
+	if len(rules.Weekdays) > 0 && !slices.Contains(rules.Weekdays, dateutil.ISOWeekday(now)) {
+		return false, nil
+	}
+	return qualified(ctx, rules, facts, now)
+}
+
+// qualified 用户是否满足活动的参与条件，不考虑每周生效的日期
+func qualified(ctx context.Context, rules *model.CampaignRules, facts *userFacts, now time.Time) (bool, error) {
+	if rules.NewUserDays > 0 {
+		registeredAt, err := facts.registeredAt(ctx)
+		if err != nil {
+			return false, err
+		}
+		if now.Sub(registeredAt) > time.Duration(rules.NewUserDays)*24*time.Hour {
+			return false, nil
+		}
+	}
+	if rules.MinLifetimePoints > 0 {
+		points, err := facts.lifetimePoints(ctx)
+		if err != nil {
+			return false, err
+		}
+		if points < rules.MinLifetimePoints {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// userFacts 判断参与条件需要的用户数据，只在活动设置了对应的条件时才查询，同一次签到中只查询一次
+type userFacts struct {
+	userID     int64
+	registered *time.Time
+	points     *int64
+}
+
+// registeredAt 用户的注册时间
+func (f *userFacts) registeredAt(ctx context.Context) (time.Time, error) {
+	if f.registered == nil {
+		user, err := query.Userinfo.WithContext(ctx).
+			Select(query.Userinfo.CreatedAt).
+			Where(query.Userinfo.UserID.Eq(f.userID)).
+			First()
+		if err != nil {
+			logging.Ctx(ctx).Error("query userinfo error", zap.Int64("user_id", f.userID), zap.Error(err))
+			return time.Time{}, err
+		}
+		f.registered = &user.CreatedAt
+	}
+	return *f.registered, nil
+}
+
+// lifetimePoints 用户累计获得的积分，还没有积分记录时为 0
+func (f *userFacts) lifetimePoints(ctx context.Context) (int64, error) {
+	if f.points == nil {
+		var points int64
+		up, err := query.UserPoint.WithContext(ctx).
+			Select(query.UserPoint.PointsTotal).
+			Where(query.UserPoint.UserID.Eq(f.userID)).
+			First()
+		switch {
+		case err == nil:
+			points = up.PointsTotal
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			logging.Ctx(ctx).Error("query user_points error", zap.Int64("user_id", f.userID), zap.Error(err))
+			return 0, err
+		}
+		f.points = &points
+	}
+	return *f.points, nil
+}
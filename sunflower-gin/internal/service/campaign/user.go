@@ -0,0 +1,82 @@
+package campaign
+
+import (
+	"context"
+	"time"
+
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/logging"
+
+	"go.uber.org/zap"
+)
+
+// UserList 用户可以参与的进行中的活动，目标活动带上用户的签到进度
+// 只在每周某几天生效的活动整个活动期间都会展示
+func UserList(ctx context.Context, userID int64) ([]*model.UserCampaignInfo, error) {
+	list, err := active(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// 1. 过滤出已经开始并且满足参与条件的活动
+	now := time.Now()
+	facts := &userFacts{userID: userID}
+	output := make([]*model.UserCampaignInfo, 0, len(list))
+	var goalIDs []int64
+	for _, c := range list {
+		if now.Before(c.StartAt) || !now.Before(c.EndAt) {
+			continue
+		}
+		rules, err := model.ParseCampaignRules(c.RulesJSON)
+		if err != nil {
+			logging.Ctx(ctx).Error("parse campaign rules error", zap.Int64("campaign_id", c.ID), zap.Error(err))
+			continue
+		}
+		ok, err := qualified(ctx, rules, facts, now)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		output = append(output, &model.UserCampaignInfo{
+			ID:          c.ID,
+			Name:        c.Name,
+			Type:        model.CampaignType(c.Type),
+			StartAt:     c.StartAt,
+			EndAt:       c.EndAt,
+			Multiplier:  c.Multiplier,
+			GoalDays:    c.GoalDays,
+			BonusPoints: c.BonusPoints,
+		})
+		if model.CampaignType(c.Type) == model.CampaignTypeCheckinGoal {
+			goalIDs = append(goalIDs, c.ID)
+		}
+	}
+	if len(goalIDs) == 0 {
+		return output, nil
+	}
+	// 2. 查询目标活动的进度，用户刚签到过时走主库
+	p := query.CampaignUserProgress
+	do := p.WithContext(ctx)
+	if dao.ReadPrimary(ctx, userID) {
+		do = do.WriteDB()
+	}
+	progress, err := do.Where(p.UserID.Eq(userID), p.CampaignID.In(goalIDs...)).Find()
+	if err != nil {
+		logging.Ctx(ctx).Error("query campaign_user_progress error", zap.Error(err))
+		return nil, err
+	}
+	progressMap := make(map[int64]*model.CampaignUserProgress, len(progress))
+	for _, v := range progress {
+		progressMap[v.CampaignID] = v
+	}
+	for _, info := range output {
+		if v, ok := progressMap[info.ID]; ok {
+			info.CheckinDays = v.CheckinDays
+			info.Completed = v.CompletedAt != nil
+		}
+	}
+	return output, nil
+}
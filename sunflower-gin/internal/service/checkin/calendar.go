@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"sunflower-gin/internal/dao"
 	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/tier"
+	"sunflower-gin/internal/tenant"
+	"sunflower-gin/pkg/logging"
 	"time"
 
 	"go.uber.org/zap"
@@ -16,10 +19,10 @@ func MonthDetail(ctx context.Context, userID int64, t time.Time) (*model.MonthDe
 	// 1. 取出当月所有签到记录和补签记录
 	checkinBitmap, retroBitmap, err := getMonthBitmap(ctx, userID, t.Year(), int(t.Month()))
 	if err != nil {
-		zap.L().Error("getMonthBitmap error", zap.Error(err))
+		logging.Ctx(ctx).Error("getMonthBitmap error", zap.Error(err))
 		return nil, err
 	}
-	zap.L().Sugar().Debugf("checkinBitmap: %031b, retroBitmap: %031b\n", checkinBitmap, retroBitmap)
+	logging.Ctx(ctx).Sugar().Debugf("checkinBitmap: %031b, retroBitmap: %031b\n", checkinBitmap, retroBitmap)
 	// 当月多少天
 	firstOfMonth := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local)
 	lastOfMonth := firstOfMonth.AddDate(0, 1, -1)
@@ -29,14 +32,24 @@ func MonthDetail(ctx context.Context, userID int64, t time.Time) (*model.MonthDe
 
 	// 2. 计算连续签到天数
 	bitmap := checkinBitmap | retroBitmap
-	zap.L().Sugar().Debugf("bitmap: %031b\n", bitmap)
+	logging.Ctx(ctx).Sugar().Debugf("bitmap: %031b\n", bitmap)
 	maxConsecutive, err := calcMonthConsecutiveDays(ctx, bitmap, dayNum)
 	if err != nil {
-		zap.L().Error("calcMonthConsecutiveDays error", zap.Error(err))
+		logging.Ctx(ctx).Error("calcMonthConsecutiveDays error", zap.Error(err))
+		return nil, err
+	}
+	// 3. 计算剩余补签次数，包含等级权益的额外次数
+	perks, err := tier.Perks(ctx, userID)
+	if err != nil {
+		logging.Ctx(ctx).Error("tier.Perks error", zap.Error(err)) // 出错时按没有等级权益处理
+		perks = &model.TierPerks{}
+	}
+	maxTimes := tenant.Reward(ctx).MaxRetroTimesPerMonth + perks.ExtraRetroTimes
+	remainRetroTimes := max(maxTimes-len(retroDays), 0) // 规则调小后不返回负数
+	retroCards, err := RetroCards(ctx, userID)
+	if err != nil {
 		return nil, err
 	}
-	// 3. 计算剩余补签次数
-	remainRetroTimes := maxRetroTimesPerMonth - len(retroDays)
 	// 4. 计算当天是否已签到
 	now := time.Now()
 	isCheckedToday := checkinBitmap&(1<<(dayNum-now.Day())) != 0
@@ -46,6 +59,7 @@ func MonthDetail(ctx context.Context, userID int64, t time.Time) (*model.MonthDe
 		RetroCheckedInDays: retroDays,
 		ConsecutiveDays:    maxConsecutive,
 		RemainRetroTimes:   remainRetroTimes,
+		RetroCards:         retroCards,
 		IsCheckedInToday:   isCheckedToday,
 	}, nil
 }
@@ -54,11 +68,11 @@ func MonthDetail(ctx context.Context, userID int64, t time.Time) (*model.MonthDe
 func IsCheckedToday(ctx context.Context, userID int64) (bool, error) {
 	now := time.Now()
 	year := now.Year()
-	key := fmt.Sprintf(yearSignKeyFormat, userID, year)
+	key := tenant.Key(ctx, fmt.Sprintf(yearSignKeyFormat, userID, year))
 	dayOffset := now.YearDay() - 1 // 偏移量从0开始
 	value, err := dao.RedisClient.GetBit(ctx, key, int64(dayOffset)).Result()
 	if err != nil {
-		zap.L().Error("getBit error", zap.Error(err))
+		logging.Ctx(ctx).Error("getBit error", zap.Error(err))
 		return false, err
 	}
 	return value == 1, nil
@@ -4,43 +4,45 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"sunflower-gin/internal/cache"
 	"sunflower-gin/internal/dao"
 	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/ledger"
+	"sunflower-gin/internal/metrics"
 	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/achievement"
+	"sunflower-gin/internal/service/campaign"
+	"sunflower-gin/internal/service/referral"
+	"sunflower-gin/internal/service/tier"
+	"sunflower-gin/internal/tenant"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+	"sunflower-gin/pkg/tracing"
 	"time"
 
 	"go.uber.org/zap"
-	"gorm.io/gorm"
 )
 
 // 每日签到业务逻辑
 
 const (
-	yearSignKeyFormat   = "user:checkins:daily:%d:%d"      // user:checkins:daily:123213131:2025
-	monthRetroKeyFormat = "user:checkins:retro:%d:%d:%02d" // user:checkins:retro:123213131:2025:01
+	// 用户ID作为 hash tag，同一个用户的签到和补签记录在集群模式下落在同一个 slot，可以放在一个 pipeline 中读取
+	yearSignKeyFormat   = "user:checkins:daily:{%d}:%d"      // user:checkins:daily:{123213131}:2025
+	monthRetroKeyFormat = "user:checkins:retro:{%d}:%d:%02d" // user:checkins:retro:{123213131}:2025:01
 )
 
-const (
-	defaultDailyPoints    = 1 // 默认每日签到积分
-	maxRetroTimesPerMonth = 3 // 每月最多补签次数
-)
-
-// 积分变更记录表的交易类型
-type PointsTransactionType int32
+// 积分变更记录表的交易类型对应的描述信息（多语言 key）
+var pointsTransactionTypeDescMap = map[model.PointsTransactionType]string{
+	model.PointsTransactionTypeDaily:       "points.desc.daily",       // 每日签到奖励
+	model.PointsTransactionTypeConsecutive: "points.desc.consecutive", // 连续签到奖励
+	model.PointsTransactionTypeRetroactive: "points.desc.retroactive", // 补签%s消耗
+}
 
 const (
-	PointsTransactionTypeDaily       PointsTransactionType = 1 // 每日签到 1
-	PointsTransactionTypeConsecutive PointsTransactionType = 2 // 连续签到 2
-	PointsTransactionTypeRetroactive PointsTransactionType = 3 // 补签 3
+	dailyCampaignDescKey = "points.desc.daily_campaign" // 每日签到奖励（%s）
 )
 
-// 积分变更记录表的交易类型对应的描述信息
-var pointsTransactionTypeDescMap = map[PointsTransactionType]string{
-	PointsTransactionTypeDaily:       "每日签到奖励",
-	PointsTransactionTypeConsecutive: "连续签到奖励",
-	PointsTransactionTypeRetroactive: "补签%s消耗",
-}
-
 // 定义连续签到的奖励类型和描述
 type ConsecutiveBonusType int32
 
@@ -51,73 +53,127 @@ const (
 	consecutiveBonus30 ConsecutiveBonusType = 4 // 月度满签
 )
 
+// 连续签到奖励的名称（多语言 key）
 var consecutiveBonusNameMap = map[ConsecutiveBonusType]string{
-	consecutiveBonus3:  "连续签到3天奖励",
-	consecutiveBonus7:  "连续签到7天奖励",
-	consecutiveBonus15: "连续签到15天奖励",
-	consecutiveBonus30: "月度满签奖励",
-}
-
-// 连续签到奖励的触发规则
-type consecutiveBonusRule struct {
-	BonusType   ConsecutiveBonusType // 奖励类型
-	TriggerDays int                  // 需要连续签到多少天才能触发这个规则
-	Points      int64                // 发放的积分数量
+	consecutiveBonus3:  "bonus.consecutive_3",  // 连续签到3天奖励
+	consecutiveBonus7:  "bonus.consecutive_7",  // 连续签到7天奖励
+	consecutiveBonus15: "bonus.consecutive_15", // 连续签到15天奖励
+	consecutiveBonus30: "bonus.consecutive_30", // 月度满签奖励
 }
 
-var consecutiveBonusRuleList = []consecutiveBonusRule{
-	{TriggerDays: 3, Points: 5, BonusType: consecutiveBonus3},
-	{TriggerDays: 7, Points: 10, BonusType: consecutiveBonus7},
-	{TriggerDays: 15, Points: 20, BonusType: consecutiveBonus15},
-	{TriggerDays: 28, Points: 100, BonusType: consecutiveBonus30},
+// String 奖励类型的名称，用于监控指标的标签
+func (t ConsecutiveBonusType) String() string {
+	switch t {
+	case consecutiveBonus3:
+		return "consecutive_3"
+	case consecutiveBonus7:
+		return "consecutive_7"
+	case consecutiveBonus15:
+		return "consecutive_15"
+	case consecutiveBonus30:
+		return "consecutive_30"
+	default:
+		return strconv.Itoa(int(t))
+	}
 }
 
 var (
-	ErrCheckedIn = errors.New("今日已签到") // 已签到
+	ErrCheckedIn = i18n.NewError("error.checkin.checked_in") // 今日已签到
 )
 
 // Daily 每日签到处理函数
 func Daily(ctx context.Context, userID int64) error {
+	ctx, span := tracing.Start(ctx, "checkin.Daily")
+	defer span.End()
 	// setbit key offset 1
 	now := time.Now()
 	year := now.Year()
-	key := fmt.Sprintf(yearSignKeyFormat, userID, year)
+	key := tenant.Key(ctx, fmt.Sprintf(yearSignKeyFormat, userID, year))
 	// 1. 获取今天是今年的第几天，算出 offset
 	// now.YearDay() // 今天是今年的第几天
 	offset := now.YearDay() - 1 // offset 从 0 开始
 	// 2、Redis 中执行 setbit 操作
-	zap.L().Sugar().Debugf("--> daily setbit key: %s, offset: %d", key, offset)
+	logging.Ctx(ctx).Sugar().Debugf("--> daily setbit key: %s, offset: %d", key, offset)
 	ret, err := dao.RedisClient.SetBit(ctx, key, int64(offset), 1).Result()
 	if err != nil {
-		zap.L().Error("daily setbit error", zap.Error(err))
+		logging.Ctx(ctx).Error("daily setbit error", zap.Error(err))
 		return err
 	}
 	if ret == 1 {
 		// 已签到
 		return ErrCheckedIn
 	}
-	// 3. 发放每日签到积分
+	metrics.CheckinTotal.Inc()
+	// 3. 计算等级权益和倍率活动额外发放的积分，出错时按没有权益和活动处理，不影响签到
+	points := tenant.Reward(ctx).DailyPoints // 每日签到积分，取自配置文件 reward 规则
+	perks, err := tier.Perks(ctx, userID)
+	if err != nil {
+		logging.Ctx(ctx).Error("tier.Perks error", zap.Error(err))
+	} else {
+		points += perks.DailyPointsBonus
+	}
+	bonus, err := campaign.CheckinBonus(ctx, userID, now, points)
+	if err != nil {
+		logging.Ctx(ctx).Error("campaign.CheckinBonus error", zap.Error(err))
+		bonus = nil
+	}
+	// 4. 发放每日签到积分
 	err = addPoints(ctx, &model.AddPointInput{
 		UserID:      userID,
-		PointAmount: defaultDailyPoints,
-		Type:        int32(PointsTransactionTypeDaily),
-		Desc:        pointsTransactionTypeDescMap[PointsTransactionTypeDaily],
+		PointAmount: points,
+		Type:        int32(model.PointsTransactionTypeDaily),
+		DescKey:     pointsTransactionTypeDescMap[model.PointsTransactionTypeDaily],
+		Bonus:       bonus,
 	})
 	if err != nil {
-		zap.L().Error("addPoints error", zap.Error(err))
+		logging.Ctx(ctx).Error("addPoints error", zap.Error(err))
 		return err
 	}
-	// 4. 发放连续签到奖励
+	// 5. 更新目标活动和邀请的签到进度，奖励发放失败不影响签到结果
+	if err := campaign.OnCheckin(ctx, userID, now); err != nil {
+		logging.Ctx(ctx).Error("[NEED_HANDLE] campaign.OnCheckin error", zap.Error(err))
+	}
+	if err := referral.OnCheckin(ctx, userID); err != nil {
+		logging.Ctx(ctx).Error("[NEED_HANDLE] referral.OnCheckin error", zap.Error(err))
+	}
+	// 6. 发放连续签到奖励
 	// 1 1 0 1 1 0 1
-	return updateConsecutiveBonus(ctx, userID, year, int(now.Month()))
+	if err := updateConsecutiveBonus(ctx, userID, year, int(now.Month())); err != nil {
+		return err
+	}
+	// 7. 计算成就和等级，失败不影响签到结果
+	evaluateProgress(ctx, userID, model.AchievementEventCheckin, now)
+	return nil
+}
+
+// evaluateProgress 签到和补签之后计算成就和等级，失败时只记录日志
+func evaluateProgress(ctx context.Context, userID int64, eventType model.AchievementEventType, now time.Time) {
+	streak, err := currentStreak(ctx, userID, now)
+	if err != nil {
+		logging.Ctx(ctx).Error("currentStreak error", zap.Error(err))
+		return
+	}
+	_, err = achievement.Evaluate(ctx, &model.AchievementEvent{
+		UserID:     userID,
+		Type:       eventType,
+		StreakDays: streak,
+	})
+	if err != nil {
+		logging.Ctx(ctx).Error("achievement.Evaluate error", zap.Error(err))
+	}
+	if _, err := tier.Evaluate(ctx, userID, streak); err != nil {
+		logging.Ctx(ctx).Error("tier.Evaluate error", zap.Error(err))
+	}
 }
 
 // updateConsecutiveBonus 更新连续签到奖励
 func updateConsecutiveBonus(ctx context.Context, userID int64, year, month int) error {
+	ctx, span := tracing.Start(ctx, "checkin.updateConsecutiveBonus")
+	defer span.End()
 	// 1. 获取当前的连续签到天数
 	checkinBitmap, retroBitmap, err := getMonthBitmap(ctx, userID, year, month)
 	if err != nil {
-		zap.L().Error("getMonthBitmap error", zap.Error(err))
+		logging.Ctx(ctx).Error("getMonthBitmap error", zap.Error(err))
 		return err
 	}
 	firstOfMonth := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
@@ -126,47 +182,50 @@ func updateConsecutiveBonus(ctx context.Context, userID int64, year, month int)
 	bitmap := checkinBitmap | retroBitmap
 	maxConsecutive, err := calcMonthConsecutiveDays(ctx, bitmap, dayNum)
 	if err != nil {
-		zap.L().Error("calcMonthConsecutiveDays error", zap.Error(err))
+		logging.Ctx(ctx).Error("calcMonthConsecutiveDays error", zap.Error(err))
 		return err
 	}
 	// 2. 计算连续签到的奖励积分
-	// 2.1 先查询用户当月领取了哪些连续签到奖励
-	bonusLogList, err := query.UserMonthlyBonusLog.WithContext(ctx).
+	// 2.1 先查询用户当月领取了哪些连续签到奖励，走主库避免主从延迟导致重复发放
+	bonusLogList, err := query.UserMonthlyBonusLog.WithContext(ctx).WriteDB().
 		Where(query.UserMonthlyBonusLog.UserID.Eq(userID)).
 		Where(query.UserMonthlyBonusLog.YearMonth.Eq(fmt.Sprintf("%d%02d", year, month))).
 		Find()
 	if err != nil {
-		zap.L().Error("query user_monthly_bonus_log error", zap.Error(err))
+		logging.Ctx(ctx).Error("query user_monthly_bonus_log error", zap.Error(err))
 		return err
 	}
 	bonusLogMap := make(map[ConsecutiveBonusType]bool, len(bonusLogList))
 	for _, v := range bonusLogList {
 		bonusLogMap[ConsecutiveBonusType(v.BonusType)] = true
 	}
-	for _, rule := range consecutiveBonusRuleList {
-		if maxConsecutive >= rule.TriggerDays && !bonusLogMap[rule.BonusType] {
+	// 连续签到奖励规则取自配置文件，支持热更新
+	for _, rule := range tenant.Reward(ctx).ConsecutiveBonus {
+		bonusType := ConsecutiveBonusType(rule.BonusType)
+		if maxConsecutive >= rule.TriggerDays && !bonusLogMap[bonusType] {
 			// 2.1.1 发放连续签到奖励积分
 			// 更新 user_points 表 和 user_points_transactions 表，
 			err := addPoints(ctx, &model.AddPointInput{
 				UserID:      userID,
 				PointAmount: rule.Points,
-				Type:        int32(PointsTransactionTypeConsecutive),
-				Desc:        consecutiveBonusNameMap[rule.BonusType],
+				Type:        int32(model.PointsTransactionTypeConsecutive),
+				DescKey:     consecutiveBonusNameMap[bonusType],
 			})
 			if err != nil {
-				zap.L().Error("[NEED_HANDLE] updateConsecutiveBonus addPoints error", zap.Error(err))
+				logging.Ctx(ctx).Error("[NEED_HANDLE] updateConsecutiveBonus addPoints error", zap.Error(err))
 				return err
 			}
+			metrics.BonusAwardedTotal.WithLabelValues(bonusType.String()).Inc()
 			// 并且记录连续签到奖励日志表 bonus_log 表
 			err = query.UserMonthlyBonusLog.WithContext(ctx).
 				Create(&model.UserMonthlyBonusLog{
 					UserID:      userID,
 					YearMonth:   fmt.Sprintf("%d%02d", year, month),
-					BonusType:   int32(rule.BonusType),
-					Description: consecutiveBonusNameMap[rule.BonusType],
+					BonusType:   rule.BonusType,
+					Description: i18n.T(i18n.DefaultLang, consecutiveBonusNameMap[bonusType]),
 				})
 			if err != nil {
-				zap.L().Error("[NEED_HANDLE] updateConsecutiveBonus create user_monthly_bonus_log error", zap.Error(err))
+				logging.Ctx(ctx).Error("[NEED_HANDLE] updateConsecutiveBonus create user_monthly_bonus_log error", zap.Error(err))
 				continue
 			}
 		}
@@ -209,28 +268,28 @@ func getMonthBitmap(ctx context.Context, userID int64, year, month int) (uint64,
 	dayNum := lastOfMonth.Day()
 	offset := firstOfMonth.YearDay() - 1 // 当月第一天的是一年的第几天-1 =  offset
 	// 取到年度签到记录 key
-	key := fmt.Sprintf(yearSignKeyFormat, userID, year)
+	key := tenant.Key(ctx, fmt.Sprintf(yearSignKeyFormat, userID, year))
 	// 从 年度签到数据中取出当月的签到记录
 	bitWidthType := fmt.Sprintf("u%d", dayNum) // u31 表示无符号 31 位整数
-	zap.L().Sugar().Debugf("key:%s bitWidthType:%s offset:%d\n", key, bitWidthType, offset)
-	values, err := dao.RedisClient.BitField(ctx, key, "GET", bitWidthType, offset).Result()
-	if err != nil {
-		zap.L().Error("获取用户签到记录失败", zap.Error(err))
+	logging.Ctx(ctx).Sugar().Debugf("key:%s bitWidthType:%s offset:%d\n", key, bitWidthType, offset)
+	// 取 月度 补签数据
+	retroKey := tenant.Key(ctx, fmt.Sprintf(monthRetroKeyFormat, userID, year, month))
+	// 两个 key 有相同的 hash tag，用一个 pipeline 读取
+	pipe := dao.RedisClient.Pipeline()
+	checkinCmd := pipe.BitField(ctx, key, "GET", bitWidthType, offset)
+	retroCmd := pipe.BitField(ctx, retroKey, "GET", bitWidthType, "#0")
+	if _, err := pipe.Exec(ctx); err != nil {
+		logging.Ctx(ctx).Error("获取用户签到和补签记录失败", zap.Error(err))
 		return 0, 0, err
 	}
-	zap.L().Sugar().Debugf("checkin values:%#v\n", values)
+	values := checkinCmd.Val()
+	logging.Ctx(ctx).Sugar().Debugf("checkin values:%#v\n", values)
 	if len(values) == 0 {
 		values = []int64{0}
 	}
 	checkinBitmap := uint64(values[0])
-	// 取 月度 补签数据
-	retroKey := fmt.Sprintf(monthRetroKeyFormat, userID, year, month)
-	retroValues, err := dao.RedisClient.BitField(ctx, retroKey, "GET", bitWidthType, "#0").Result()
-	if err != nil {
-		zap.L().Error("获取用户补签记录失败", zap.Error(err))
-		return 0, 0, err
-	}
-	zap.L().Sugar().Debugf("retro values:%#v\n", values)
+	retroValues := retroCmd.Val()
+	logging.Ctx(ctx).Sugar().Debugf("retro values:%#v\n", values)
 	if len(retroValues) == 0 { // 用户当月可能没有补签记录
 		retroValues = []int64{0}
 	}
@@ -239,48 +298,55 @@ func getMonthBitmap(ctx context.Context, userID int64, year, month int) (uint64,
 }
 
 func addPoints(ctx context.Context, input *model.AddPointInput) error {
-	// 需要分别更新 user_points 表和 user_points_transactions 表
-	// 3.1 查询 user_points 表
-	userPoint, err := query.UserPoint.WithContext(ctx).
-		Where(query.UserPoint.UserID.Eq(input.UserID)).
-		First()
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		zap.L().Error("query user_points error", zap.Error(err))
-		return err
+	ctx, span := tracing.Start(ctx, "checkin.addPoints")
+	defer span.End()
+	// 签到奖励从系统发放账户转入用户钱包，记账时同步更新 user_points 表和 user_points_transactions 表
+	entry := &model.LedgerEntryInput{
+		Type:     model.PointsTransactionType(input.Type),
+		DescKey:  input.DescKey,
+		DescArgs: input.DescArgs,
+		Lines: []*model.LedgerLine{
+			{AccountType: model.LedgerAccountTypeIssuance, Amount: -input.PointAmount},
+			{AccountType: model.LedgerAccountTypeUserWallet, OwnerID: input.UserID, Amount: input.PointAmount},
+		},
 	}
-	if userPoint == nil || userPoint.ID == 0 {
-		// 没有查到记录，说明是第一次来签到
-		userPoint = &model.UserPoint{
-			UserID: input.UserID,
+	// 倍率活动额外的积分和签到积分记在同一笔流水中，设置了预算的活动从活动奖池转出
+	bonus := input.Bonus
+	if bonus != nil {
+		entry.DescKey, entry.DescArgs = dailyCampaignDescKey, []any{bonus.CampaignName}
+		entry.Ext.CampaignID = bonus.CampaignID
+		entry.Lines[1].Amount += bonus.Points
+		if bonus.FromPool {
+			entry.Lines = append(entry.Lines, &model.LedgerLine{
+				AccountType: model.LedgerAccountTypeCampaignPool, OwnerID: bonus.CampaignID, Amount: -bonus.Points,
+			})
+		} else {
+			entry.Lines[0].Amount -= bonus.Points
 		}
 	}
-	// 更新积分
-	userPoint.Points = userPoint.Points + input.PointAmount
-	userPoint.PointsTotal = userPoint.PointsTotal + input.PointAmount
-	// 在事务中更新 user_points 表和 user_points_transactions 表
-	err = query.Q.Transaction(func(tx *query.Query) error {
-		// 更新 user_points 表
-		if err := tx.UserPoint.WithContext(ctx).Save(userPoint); err != nil {
-			zap.L().Error("tx save user_points error", zap.Error(err))
-			return err
-		}
-		// 更新 user_points_transactions 表
-		if err := tx.UserPointsTransaction.WithContext(ctx).
-			Create(&model.UserPointsTransaction{
-				UserID:          input.UserID,
-				PointsChange:    input.PointAmount,
-				CurrentBalance:  userPoint.Points,
-				TransactionType: input.Type,
-				Description:     input.Desc,
-			}); err != nil {
-			zap.L().Error("tx create user_points_transactions error", zap.Error(err))
-			return err
-		}
-		return nil
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		_, err := ledger.Post(ctx, tx, entry)
+		return err
 	})
+	if bonus != nil && errors.Is(err, ledger.ErrInsufficientBalance) {
+		// 只有活动奖池可能余额不足，奖池用完之后只发放签到积分
+		logging.Ctx(ctx).Warn("campaign budget exhausted", zap.Int64("campaign_id", bonus.CampaignID))
+		metrics.CampaignBudgetExhaustedTotal.WithLabelValues(model.CampaignTypeMultiplier.String()).Inc()
+		next := *input
+		next.Bonus = nil
+		return addPoints(ctx, &next)
+	}
 	if err != nil {
-		zap.L().Error("tx commit failed", zap.Error(err))
+		logging.Ctx(ctx).Error("tx commit failed", zap.Error(err))
 		return err
 	}
+	dao.MarkWritten(ctx, input.UserID)
+	cache.Delete(ctx, cache.PointsSummaryKey(input.UserID))
+	issued := input.PointAmount
+	if bonus != nil {
+		issued += bonus.Points
+		metrics.CampaignPointsTotal.WithLabelValues(model.CampaignTypeMultiplier.String()).Add(float64(bonus.Points))
+	}
+	metrics.PointsIssuedTotal.WithLabelValues(model.PointsTransactionType(input.Type).String()).Add(float64(issued))
 	return nil
 }
@@ -4,56 +4,83 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sunflower-gin/internal/cache"
 	"sunflower-gin/internal/dao"
 	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/ledger"
+	"sunflower-gin/internal/metrics"
 	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/tier"
+	"sunflower-gin/internal/tenant"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+	"sunflower-gin/pkg/tracing"
 	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // 补签相关的业务逻辑
 
-const (
-	defaultRetroCostPoints = 100 // 补签消耗积分
-)
-
 var (
-	ErrInvalidRetroDate    = errors.New("无效的补签日期")
-	ErrRetroNoTimes        = errors.New("本月已经没有补签次数了")
-	ErrRetroNoEnoughPoints = errors.New("积分不足，无法补签")
+	ErrInvalidRetroDate    = i18n.NewError("error.checkin.invalid_retro_date")     // 无效的补签日期
+	ErrRetroNoTimes        = i18n.NewError("error.checkin.retro_no_times")         // 本月已经没有补签次数了
+	ErrRetroNoEnoughPoints = i18n.NewError("error.checkin.retro_no_enough_points") // 积分不足，无法补签
 )
 
 // Retroactive 补签逻辑
 func Retroactive(ctx context.Context, userID int64, date time.Time) error {
+	ctx, span := tracing.Start(ctx, "checkin.Retroactive")
+	defer span.End()
 	// 1. 补签日期的校验（涉及业务逻辑的参数有效校验）
-	if err := checkRetroDate(ctx, userID, date); err != nil {
+	reward := tenant.Reward(ctx) // 本次补签使用同一份规则，避免中途热更新导致前后不一致
+	perks, err := tier.Perks(ctx, userID)
+	if err != nil {
+		logging.Ctx(ctx).Error("tier.Perks error", zap.Error(err)) // 出错时按没有等级权益处理
+		perks = &model.TierPerks{}
+	}
+	if err := checkRetroDate(ctx, userID, date, reward.MaxRetroTimesPerMonth+perks.ExtraRetroTimes); err != nil {
 		return err
 	}
 	// 2. 执行补签逻辑
 	// 2.1 在 Redis 中标记补签的日期， setbit 设置补签记录
-	key := fmt.Sprintf(monthRetroKeyFormat, userID, date.Year(), int(date.Month()))
+	key := tenant.Key(ctx, fmt.Sprintf(monthRetroKeyFormat, userID, date.Year(), int(date.Month())))
 	offset := date.Day() - 1 // 0 base index
-	err := dao.RedisClient.SetBit(ctx, key, int64(offset), 1).Err()
+	err = dao.RedisClient.SetBit(ctx, key, int64(offset), 1).Err()
 	if err != nil {
-		zap.L().Error("setbit error", zap.Error(err))
+		logging.Ctx(ctx).Error("setbit error", zap.Error(err))
 		return err
 	}
-	// 2.2 补签消耗积分，签到增加积分，增加积分记录到数据库
-	if err := retroWithTransaction(ctx, userID, date); err != nil {
+	// 2.2 补签优先使用补签卡，没有补签卡时消耗积分，增加积分记录到数据库
+	usedCard, err := retroWithTransaction(ctx, userID, date, reward.RetroCostPoints)
+	if err != nil {
 		// 如果补签逻辑执行失败，需要回滚 Redis 中的标记
 		if err := dao.RedisClient.SetBit(ctx, key, int64(offset), 0).Err(); err != nil {
 			return fmt.Errorf("retroWithTransaction rollback retro bit error:%w", err)
 		}
 		return err
 	}
+	dao.MarkWritten(ctx, userID)
+	cache.Delete(ctx, cache.PointsSummaryKey(userID))
+	metrics.RetroCheckinTotal.Inc()
+	if usedCard {
+		metrics.RetroCardsUsedTotal.Inc()
+	} else {
+		metrics.PointsSpentTotal.WithLabelValues(model.PointsTransactionTypeRetroactive.String()).Add(float64(reward.RetroCostPoints))
+	}
 	// 3. 发放可能存在的连续签到奖励
-	return updateConsecutiveBonus(ctx, userID, date.Year(), int(date.Month()))
+	if err := updateConsecutiveBonus(ctx, userID, date.Year(), int(date.Month())); err != nil {
+		return err
+	}
+	// 4. 计算成就和等级，补签可能补上中断的连续签到
+	evaluateProgress(ctx, userID, model.AchievementEventRetroactive, time.Now())
+	return nil
 }
 
 // checkRetroDate 校验补签日期是否合法
-func checkRetroDate(ctx context.Context, userID int64, date time.Time) error {
+func checkRetroDate(ctx context.Context, userID int64, date time.Time, maxTimes int) error {
 	// 1. 补签日期不能是今天或者未来的日期
 	// 2. 补签的日期只能是当前月份的
 	now := time.Now()
@@ -65,7 +92,7 @@ func checkRetroDate(ctx context.Context, userID int64, date time.Time) error {
 	// 3. 补签的日期不能是已经签到或者补签的日期
 	checkinBitmap, retroBitmap, err := getMonthBitmap(ctx, userID, date.Year(), int(date.Month()))
 	if err != nil {
-		zap.L().Error("getMonthBitmap error", zap.Error(err))
+		logging.Ctx(ctx).Error("getMonthBitmap error", zap.Error(err))
 		return err
 	}
 	bitmap := checkinBitmap | retroBitmap // 1111111111111111111011111111111111
@@ -75,62 +102,88 @@ func checkRetroDate(ctx context.Context, userID int64, date time.Time) error {
 	if bitmap&(1<<uint(days-date.Day())) != 0 {
 		return ErrInvalidRetroDate
 	}
-	// 4. 补签的次数不能超过限制（3次）
+	// 4. 补签的次数不能超过每月限制
 	// 统计 retroBitmap 里有几个二进制位是1
 	count := 0
 	for retroBitmap != 0 {
 		retroBitmap &= (retroBitmap - 1) // 去掉最右边的二进制位，直到为0
 		count++
 	}
-	if count >= 3 {
+	if count >= maxTimes {
 		return ErrRetroNoTimes
 	}
 	return nil
 }
 
-// 补签逻辑，涉及到事务的处理
-func retroWithTransaction(ctx context.Context, userID int64, date time.Time) error {
-	return query.Q.Transaction(func(tx *query.Query) error {
-		// 1. 查询用户当前的积分，积分不够也不能补签
-		var (
-			upInst *model.UserPoint
-			err    error
-		)
-		upInst, err = tx.UserPoint.WithContext(ctx).
-			Where(tx.UserPoint.UserID.Eq(userID)).
-			First()
+// 补签逻辑，有补签卡时使用一张补签卡，否则消耗的积分从用户钱包转入销毁账户，积分不够时不能补签
+// 返回是否使用了补签卡
+func retroWithTransaction(ctx context.Context, userID int64, date time.Time, costPoints int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "checkin.retroWithTransaction")
+	defer span.End()
+	// 配置为免费补签时没有积分变动，不记账，也不使用补签卡，只写一条 0 积分的补签流水
+	if costPoints == 0 {
+		return false, query.Q.Transaction(func(tx *query.Query) error {
+			return retroRecord(ctx, tx, userID, date, false)
+		})
+	}
+	usedCard := false
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		used, err := useRetroCard(ctx, tx, userID)
 		if err != nil {
-			if !errors.Is(err, gorm.ErrRecordNotFound) {
-				return err // 非预期的数据库查询错误，需要返回
-			}
-			upInst = &model.UserPoint{ // 初始化一个新的 UserPoint 实例，默认0积分
-				UserID: userID,
-			}
-		}
-		if upInst.Points < defaultRetroCostPoints {
-			return ErrRetroNoEnoughPoints
-		}
-		// 2. 扣除积分
-		pointsChange := -defaultRetroCostPoints          // 扣除积分
-		newPoints := upInst.Points + int64(pointsChange) // 当前积分值
-		// 3. 增加积分记录流水
-		retroCostRecord := &model.UserPointsTransaction{
-			UserID:          userID,
-			PointsChange:    int64(pointsChange),
-			CurrentBalance:  newPoints,
-			TransactionType: int32(PointsTransactionTypeRetroactive),
-			Description:     fmt.Sprintf(pointsTransactionTypeDescMap[PointsTransactionTypeRetroactive], date.Format(time.DateOnly)),
-		}
-		if err := tx.WithContext(ctx).UserPointsTransaction.Create(retroCostRecord); err != nil {
-			zap.L().Error("create retroCostRecord error", zap.Error(err))
 			return err
 		}
-		// 4. 更新用户积分
-		upInst.Points = newPoints
-		if err := tx.UserPoint.WithContext(ctx).Save(upInst); err != nil {
-			zap.L().Error("update user points error", zap.Error(err))
-			return err
+		// 使用补签卡时同样没有积分变动，写一条 0 积分的补签流水并标记使用了补签卡
+		if used {
+			usedCard = true
+			return retroRecord(ctx, tx, userID, date, true)
 		}
-		return nil
+		_, err = ledger.Post(ctx, tx, &model.LedgerEntryInput{
+			Type:     model.PointsTransactionTypeRetroactive,
+			DescKey:  pointsTransactionTypeDescMap[model.PointsTransactionTypeRetroactive],
+			DescArgs: []any{date.Format(time.DateOnly)},
+			Lines: []*model.LedgerLine{
+				{AccountType: model.LedgerAccountTypeUserWallet, OwnerID: userID, Amount: -costPoints},
+				{AccountType: model.LedgerAccountTypeBurn, Amount: costPoints},
+			},
+		})
+		if errors.Is(err, ledger.ErrInsufficientBalance) {
+			return ErrRetroNoEnoughPoints
+		}
+		return err
 	})
+	return usedCard, err
+}
+
+// retroRecord 在 tx 事务中写一条 0 积分的补签流水，让积分明细中仍然能看到免费补签和使用补签卡的补签
+// 流水不经过复式记账，加锁读取 user_points，流水中的余额与前后的积分变动保持连续
+func retroRecord(ctx context.Context, tx *query.Query, userID int64, date time.Time, usedCard bool) error {
+	upInst, err := tx.UserPoint.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(tx.UserPoint.UserID.Eq(userID)).
+		First()
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logging.Ctx(ctx).Error("query user_points error", zap.Error(err))
+		return err
+	}
+	var balance int64
+	if upInst != nil {
+		balance = upInst.Points
+	}
+	descKey := pointsTransactionTypeDescMap[model.PointsTransactionTypeRetroactive]
+	descArgs := []any{date.Format(time.DateOnly)}
+	extJSON, err := model.TransactionExt{DescKey: descKey, DescArgs: descArgs, RetroCard: usedCard}.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := tx.UserPointsTransaction.WithContext(ctx).Create(&model.UserPointsTransaction{
+		UserID:          userID,
+		CurrentBalance:  balance,
+		TransactionType: int32(model.PointsTransactionTypeRetroactive),
+		Description:     i18n.T(i18n.DefaultLang, descKey, descArgs...), // 入库的是默认语言，展示时按 ExtJSON 重新翻译
+		ExtJSON:         extJSON,
+	}); err != nil {
+		logging.Ctx(ctx).Error("create retroCostRecord error", zap.Error(err))
+		return err
+	}
+	return nil
 }
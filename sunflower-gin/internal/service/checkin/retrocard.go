@@ -0,0 +1,66 @@
+package checkin
+
+import (
+	"context"
+	"errors"
+
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/logging"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 补签卡
+// 补签卡可以通过幸运抽奖获得，补签时优先使用补签卡，不消耗积分，每月的补签次数限制不变
+
+// GrantRetroCards 在 tx 事务中给用户发放补签卡
+func GrantRetroCards(ctx context.Context, tx *query.Query, userID, count int64) error {
+	// gen 不允许在 OnConflict 中使用表达式，直接用 gorm
+	rc := tx.UserRetroCard
+	err := rc.WithContext(ctx).UnderlyingDB().
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: rc.UserID.ColumnName().String()}},
+			DoUpdates: clause.Assignments(map[string]any{rc.Balance.ColumnName().String(): gorm.Expr("balance + ?", count)}),
+		}).
+		Create(&model.UserRetroCard{UserID: userID, Balance: count}).Error
+	if err != nil {
+		logging.Ctx(ctx).Error("upsert user_retro_cards error", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// RetroCards 用户剩余的补签卡张数
+func RetroCards(ctx context.Context, userID int64) (int64, error) {
+	rc := query.UserRetroCard
+	do := rc.WithContext(ctx)
+	if dao.ReadPrimary(ctx, userID) {
+		do = do.WriteDB()
+	}
+	row, err := do.Select(rc.Balance).Where(rc.UserID.Eq(userID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		logging.Ctx(ctx).Error("query user_retro_cards error", zap.Error(err))
+		return 0, err
+	}
+	return row.Balance, nil
+}
+
+// useRetroCard 在 tx 事务中使用一张补签卡，没有补签卡时返回 false
+func useRetroCard(ctx context.Context, tx *query.Query, userID int64) (bool, error) {
+	rc := tx.UserRetroCard
+	info, err := rc.WithContext(ctx).
+		Where(rc.UserID.Eq(userID), rc.Balance.Gt(0)).
+		UpdateSimple(rc.Balance.Sub(1))
+	if err != nil {
+		logging.Ctx(ctx).Error("update user_retro_cards error", zap.Error(err))
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
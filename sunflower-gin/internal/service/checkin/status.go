@@ -0,0 +1,35 @@
+package checkin
+
+import (
+	"context"
+	"math/bits"
+	"time"
+
+	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/logging"
+
+	"go.uber.org/zap"
+)
+
+// 签到状态，供其它服务通过内部接口查询
+
+// Status 用户今天是否签到、截止到今天的连续签到天数和本月签到的天数
+func Status(ctx context.Context, userID int64) (*model.CheckinStatusOutput, error) {
+	now := time.Now()
+	checkinBitmap, retroBitmap, err := getMonthBitmap(ctx, userID, now.Year(), int(now.Month()))
+	if err != nil {
+		logging.Ctx(ctx).Error("getMonthBitmap error", zap.Error(err))
+		return nil, err
+	}
+	streak, err := currentStreak(ctx, userID, now)
+	if err != nil {
+		logging.Ctx(ctx).Error("currentStreak error", zap.Error(err))
+		return nil, err
+	}
+	dayNum := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local).AddDate(0, 1, -1).Day()
+	return &model.CheckinStatusOutput{
+		IsCheckedInToday: checkinBitmap&(1<<uint(dayNum-now.Day())) != 0,
+		ConsecutiveDays:  streak,
+		MonthDays:        bits.OnesCount64(checkinBitmap | retroBitmap),
+	}, nil
+}
@@ -0,0 +1,37 @@
+package checkin
+
+import (
+	"context"
+	"time"
+)
+
+const maxStreakMonths = 13 // 计算连续签到天数时最多往前查多少个月
+
+// currentStreak 截止到今天的连续签到天数，补签的日期也算签到，可以跨月和跨年
+// 今天还没有签到时从昨天开始算，中断之前的连续签到不计入
+func currentStreak(ctx context.Context, userID int64, now time.Time) (int, error) {
+	streak := 0
+	year, month, day := now.Year(), int(now.Month()), now.Day()
+	for i := range maxStreakMonths {
+		checkinBitmap, retroBitmap, err := getMonthBitmap(ctx, userID, year, month)
+		if err != nil {
+			return 0, err
+		}
+		bitmap := checkinBitmap | retroBitmap
+		dayNum := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local).AddDate(0, 1, -1).Day()
+		d := day
+		if i == 0 && bitmap&(1<<uint(dayNum-d)) == 0 {
+			d-- // 今天还没有签到
+		}
+		for ; d >= 1; d-- {
+			if bitmap&(1<<uint(dayNum-d)) == 0 {
+				return streak, nil
+			}
+			streak++
+		}
+		// 这个月剩下的天数都签到了，继续查上个月
+		prev := time.Date(year, time.Month(month), 0, 0, 0, 0, 0, time.Local)
+		year, month, day = prev.Year(), int(prev.Month()), prev.Day()
+	}
+	return streak, nil
+}
@@ -0,0 +1,305 @@
+package draw
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"sunflower-gin/internal/cache"
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/ledger"
+	"sunflower-gin/internal/metrics"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/checkin"
+	"sunflower-gin/internal/service/progress"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+	"sunflower-gin/pkg/tracing"
+
+	"go.uber.org/zap"
+	"gorm.io/gen/field"
+	"gorm.io/gorm/clause"
+)
+
+// 幸运抽奖
+// 奖品池在配置文件 draw.prizes 中，用户每次每日签到后可以抽奖一次，补签不能抽奖
+// 每次抽奖使用 crypto/rand 生成新的服务端种子，抽中的位置由种子通过 HMAC-SHA256 计算，种子和结果都保存下来，
+// 用于审计时复现抽奖结果，计算方法见 scripts/sql/009_lucky_draws.sql
+// 种子在抽奖时才生成，抽奖前没有公开任何承诺，不能用来向用户证明结果没有被操纵
+
+const (
+	seedLength     = 32
+	couponLength   = 12
+	couponAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // 去掉容易混淆的 0/O、1/I
+	drawDescKey    = "points.desc.draw"                 // 幸运抽奖：%s
+	prizeNameKey   = "draw.prize.%s"                    // 奖品名称的多语言 key
+)
+
+var (
+	ErrDisabled     = i18n.NewError("error.draw.disabled")       // 抽奖活动没有开启
+	ErrNotCheckedIn = i18n.NewError("error.draw.not_checked_in") // 今天还没有签到
+	ErrAlreadyDrawn = i18n.NewError("error.draw.already_drawn")  // 今天已经抽过奖了
+	ErrNoPrize      = i18n.NewError("error.draw.no_prize")       // 奖品已经抽完了
+)
+
+// Draw 今天签到后的抽奖，抽中的奖品在同一个事务中发放
+func Draw(ctx context.Context, userID int64) (*model.DrawInfo, error) {
+	ctx, span := tracing.Start(ctx, "draw.Draw")
+	defer span.End()
+	cfg := conf.Get().Draw // 本次抽奖使用同一份奖品池，避免中途热更新导致前后不一致
+	if !cfg.Enabled {
+		return nil, ErrDisabled
+	}
+	// 1. 今天已经签到才能抽奖
+	checked, err := checkin.IsCheckedToday(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !checked {
+		return nil, ErrNotCheckedIn
+	}
+	// 2. 生成服务端种子
+	now := time.Now()
+	seed := make([]byte, seedLength)
+	if _, err := rand.Read(seed); err != nil {
+		logging.Ctx(ctx).Error("generate draw seed error", zap.Error(err))
+		return nil, err
+	}
+	record := &model.LuckyDraw{
+		UserID:     userID,
+		DrawDate:   time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+		ServerSeed: hex.EncodeToString(seed),
+	}
+	var prize *conf.DrawPrize
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		// 3. 占用今天的抽奖次数，并发请求只有一个能成功
+		res := tx.LuckyDraw.WithContext(ctx).UnderlyingDB().
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(record)
+		if res.Error != nil {
+			logging.Ctx(ctx).Error("create lucky_draws error", zap.Error(res.Error))
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrAlreadyDrawn
+		}
+		// 4. 抽奖，抽中的奖品库存不足时排除这个奖品，nonce 加一重新抽取
+		candidates, err := available(ctx, tx, cfg.Prizes)
+		if err != nil {
+			return err
+		}
+		for nonce := int32(0); ; nonce++ {
+			if len(candidates) == 0 {
+				return ErrNoPrize
+			}
+			var total int64
+			for _, p := range candidates {
+				total += p.Weight
+			}
+			pos := roll(seed, fmt.Sprintf("%d:%s:%d", userID, record.DrawDate.Format(time.DateOnly), nonce), total)
+			idx := pick(candidates, pos)
+			ok, err := takeStock(ctx, tx, candidates[idx])
+			if err != nil {
+				return err
+			}
+			if ok {
+				prize = candidates[idx]
+				record.Nonce, record.Roll, record.TotalWeight = nonce, pos, total
+				break
+			}
+			candidates = slices.Delete(candidates, idx, idx+1)
+		}
+		// 5. 发放奖品
+		record.PrizeCode, record.PrizeType, record.Amount = prize.Code, int32(model.ParseDrawPrizeType(prize.Type)), prize.Amount
+		if err := award(ctx, tx, record, cfg.CouponTTL, now); err != nil {
+			return err
+		}
+		// 6. 保存抽奖结果
+		ld := tx.LuckyDraw
+		updates := []field.AssignExpr{
+			ld.PrizeCode.Value(record.PrizeCode),
+			ld.PrizeType.Value(record.PrizeType),
+			ld.Amount.Value(record.Amount),
+			ld.Nonce.Value(record.Nonce),
+			ld.Roll.Value(record.Roll),
+			ld.TotalWeight.Value(record.TotalWeight),
+		}
+		if record.CouponExpiresAt != nil {
+			updates = append(updates, ld.CouponCode.Value(record.CouponCode), ld.CouponExpiresAt.Value(*record.CouponExpiresAt))
+		}
+		if _, err := ld.WithContext(ctx).Where(ld.ID.Eq(record.ID)).UpdateSimple(updates...); err != nil {
+			logging.Ctx(ctx).Error("update lucky_draws error", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 种子写入日志用于审计
+	logging.Ctx(ctx).Info("lucky draw",
+		zap.Int64("user_id", userID),
+		zap.Int64("draw_id", record.ID),
+		zap.String("server_seed", record.ServerSeed),
+		zap.Int32("nonce", record.Nonce),
+		zap.Int64("roll", record.Roll),
+		zap.Int64("total_weight", record.TotalWeight),
+		zap.String("prize", record.PrizeCode),
+	)
+	dao.MarkWritten(ctx, userID)
+	prizeType := model.DrawPrizeType(record.PrizeType)
+	metrics.DrawsTotal.WithLabelValues(prizeType.String()).Inc()
+	if prizeType == model.DrawPrizeTypePoints && record.Amount > 0 {
+		cache.Delete(ctx, cache.PointsSummaryKey(userID))
+		metrics.PointsIssuedTotal.WithLabelValues(model.PointsTransactionTypeDraw.String()).Add(float64(record.Amount))
+		// 累计获得的积分增加，重新计算成就和等级，失败不影响抽奖结果
+		progress.OnPointsEarned(ctx, userID)
+	}
+	return toInfo(i18n.FromContext(ctx), record), nil
+}
+
+// available 有库存的奖品，按配置的顺序排列
+func available(ctx context.Context, tx *query.Query, prizes []conf.DrawPrize) ([]*conf.DrawPrize, error) {
+	issued, err := issuedMap(ctx, tx.DrawPrizeStock.WithContext(ctx), prizes)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*conf.DrawPrize, 0, len(prizes))
+	for i := range prizes {
+		p := &prizes[i]
+		if p.Stock > 0 && issued[p.Code] >= p.Stock {
+			continue
+		}
+		list = append(list, p)
+	}
+	return list, nil
+}
+
+// issuedMap 有库存限制的奖品已经发出的数量
+func issuedMap(ctx context.Context, do query.IDrawPrizeStockDo, prizes []conf.DrawPrize) (map[string]int64, error) {
+	var codes []string
+	for _, p := range prizes {
+		if p.Stock > 0 {
+			codes = append(codes, p.Code)
+		}
+	}
+	issued := make(map[string]int64, len(codes))
+	if len(codes) == 0 {
+		return issued, nil
+	}
+	s := query.DrawPrizeStock
+	rows, err := do.Where(s.Code.In(codes...)).Find()
+	if err != nil {
+		logging.Ctx(ctx).Error("query draw_prize_stock error", zap.Error(err))
+		return nil, err
+	}
+	for _, v := range rows {
+		issued[v.Code] = v.Issued
+	}
+	return issued, nil
+}
+
+// takeStock 扣减奖品库存，库存不足时返回 false，不限制库存的奖品直接返回 true
+func takeStock(ctx context.Context, tx *query.Query, prize *conf.DrawPrize) (bool, error) {
+	if prize.Stock == 0 {
+		return true, nil
+	}
+	s := tx.DrawPrizeStock
+	if err := s.WithContext(ctx).UnderlyingDB().
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.DrawPrizeStock{Code: prize.Code}).Error; err != nil {
+		logging.Ctx(ctx).Error("create draw_prize_stock error", zap.Error(err))
+		return false, err
+	}
+	info, err := s.WithContext(ctx).
+		Where(s.Code.Eq(prize.Code), s.Issued.Lt(prize.Stock)).
+		UpdateSimple(s.Issued.Add(1))
+	if err != nil {
+		logging.Ctx(ctx).Error("update draw_prize_stock error", zap.Error(err))
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+// award 在 tx 事务中发放奖品，积分从系统发放账户转入用户钱包
+func award(ctx context.Context, tx *query.Query, record *model.LuckyDraw, couponTTL time.Duration, now time.Time) error {
+	switch model.DrawPrizeType(record.PrizeType) {
+	case model.DrawPrizeTypePoints:
+		if record.Amount == 0 {
+			return nil
+		}
+		_, err := ledger.Post(ctx, tx, &model.LedgerEntryInput{
+			Type:     model.PointsTransactionTypeDraw,
+			DescKey:  drawDescKey,
+			DescArgs: []any{i18n.T(i18n.DefaultLang, fmt.Sprintf(prizeNameKey, record.PrizeCode))},
+			Ext:      model.TransactionExt{DrawID: record.ID},
+			Lines: []*model.LedgerLine{
+				{AccountType: model.LedgerAccountTypeIssuance, Amount: -record.Amount},
+				{AccountType: model.LedgerAccountTypeUserWallet, OwnerID: record.UserID, Amount: record.Amount},
+			},
+		})
+		return err
+	case model.DrawPrizeTypeRetroCard:
+		if record.Amount == 0 {
+			return nil
+		}
+		return checkin.GrantRetroCards(ctx, tx, record.UserID, record.Amount)
+	case model.DrawPrizeTypeCoupon:
+		code, err := newCouponCode()
+		if err != nil {
+			logging.Ctx(ctx).Error("generate coupon code error", zap.Error(err))
+			return err
+		}
+		expiresAt := now.Add(couponTTL)
+		record.CouponCode, record.CouponExpiresAt = code, &expiresAt
+	}
+	return nil
+}
+
+// roll 用种子对 message 计算 HMAC-SHA256，取前 8 字节按大端转成整数后对 total 取余，得到 [0, total) 范围内的位置
+// 结果超出 total 整数倍的部分时 message 后面加上 :1、:2 ... 重新计算，避免取余带来的偏差
+func roll(seed []byte, message string, total int64) int64 {
+	limit := math.MaxUint64 - math.MaxUint64%uint64(total)
+	msg := message
+	for round := 1; ; round++ {
+		mac := hmac.New(sha256.New, seed)
+		mac.Write([]byte(msg))
+		v := binary.BigEndian.Uint64(mac.Sum(nil)[:8])
+		if v < limit {
+			return int64(v % uint64(total))
+		}
+		msg = fmt.Sprintf("%s:%d", message, round)
+	}
+}
+
+// pick 按权重区间找到 pos 对应的奖品下标
+func pick(prizes []*conf.DrawPrize, pos int64) int {
+	for i, p := range prizes {
+		if pos < p.Weight {
+			return i
+		}
+		pos -= p.Weight
+	}
+	return len(prizes) - 1
+}
+
+// newCouponCode 随机生成优惠券码
+func newCouponCode() (string, error) {
+	b := make([]byte, couponLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = couponAlphabet[int(b[i])%len(couponAlphabet)]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,98 @@
+package draw
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"sunflower-gin/internal/conf"
+)
+
+func TestRoll(t *testing.T) {
+	seed := []byte("0123456789abcdef0123456789abcdef")
+	tests := []struct {
+		name  string
+		total int64
+	}{
+		{"single prize", 1},
+		{"small total", 3},
+		{"weights sum", 1000},
+		{"not a power of two", 997},
+		{"large total", math.MaxInt64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := range 1000 {
+				msg := fmt.Sprintf("1:2025-07-01:%d", i)
+				pos := roll(seed, msg, tt.total)
+				if pos < 0 || pos >= tt.total {
+					t.Fatalf("roll(%q, %d) = %d, out of range", msg, tt.total, pos)
+				}
+				if again := roll(seed, msg, tt.total); again != pos {
+					t.Fatalf("roll(%q, %d) is not deterministic: %d != %d", msg, tt.total, pos, again)
+				}
+			}
+		})
+	}
+}
+
+// TestRollUniform 每个位置被抽中的次数接近平均值，卡方检验的阈值取自由度 k-1 时 p=0.001 的临界值
+func TestRollUniform(t *testing.T) {
+	seed := []byte("0123456789abcdef0123456789abcdef")
+	tests := []struct {
+		total    int64
+		n        int
+		critical float64
+	}{
+		{total: 2, n: 20000, critical: 10.83},
+		{total: 3, n: 30000, critical: 13.82},
+		{total: 10, n: 50000, critical: 27.88},
+		{total: 100, n: 100000, critical: 148.23},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("total=%d", tt.total), func(t *testing.T) {
+			counts := make([]int, tt.total)
+			for i := range tt.n {
+				counts[roll(seed, fmt.Sprintf("%d:2025-07-01:0", i), tt.total)]++
+			}
+			expected := float64(tt.n) / float64(tt.total)
+			var chi2 float64
+			for _, c := range counts {
+				d := float64(c) - expected
+				chi2 += d * d / expected
+			}
+			if chi2 > tt.critical {
+				t.Errorf("chi-square = %.2f, want <= %.2f, counts = %v", chi2, tt.critical, counts)
+			}
+		})
+	}
+}
+
+func TestPick(t *testing.T) {
+	prizes := []*conf.DrawPrize{
+		{Code: "a", Weight: 10},
+		{Code: "b", Weight: 1},
+		{Code: "c", Weight: 5},
+		{Code: "d", Weight: 20},
+	}
+	tests := []struct {
+		pos  int64
+		want string
+	}{
+		{0, "a"},
+		{9, "a"},
+		{10, "b"},
+		{11, "c"},
+		{15, "c"},
+		{16, "d"},
+		{35, "d"},
+		{36, "d"}, // 超出权重之和时取最后一个，正常抽奖不会出现
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("pos=%d", tt.pos), func(t *testing.T) {
+			if got := prizes[pick(prizes, tt.pos)].Code; got != tt.want {
+				t.Errorf("pick(%d) = %s, want %s", tt.pos, got, tt.want)
+			}
+		})
+	}
+}
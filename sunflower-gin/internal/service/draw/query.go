@@ -0,0 +1,124 @@
+package draw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/checkin"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Pool 奖品池、中奖概率和当前用户今天的抽奖状态
+func Pool(ctx context.Context, userID int64) (*model.DrawPoolOutput, error) {
+	cfg := conf.Get().Draw
+	primary := dao.ReadPrimary(ctx, userID)
+	// 1. 奖品的剩余库存和概率
+	s := query.DrawPrizeStock
+	issued, err := issuedMap(ctx, s.WithContext(ctx), cfg.Prizes)
+	if err != nil {
+		return nil, err
+	}
+	var total int64
+	for _, p := range cfg.Prizes {
+		if p.Stock == 0 || issued[p.Code] < p.Stock {
+			total += p.Weight
+		}
+	}
+	lang := i18n.FromContext(ctx)
+	output := &model.DrawPoolOutput{
+		Enabled: cfg.Enabled,
+		Prizes:  make([]*model.DrawPrizeInfo, 0, len(cfg.Prizes)),
+	}
+	for _, p := range cfg.Prizes {
+		info := &model.DrawPrizeInfo{
+			Code:   p.Code,
+			Name:   i18n.T(lang, fmt.Sprintf(prizeNameKey, p.Code)),
+			Type:   model.ParseDrawPrizeType(p.Type),
+			Amount: p.Amount,
+			Stock:  p.Stock,
+		}
+		if p.Stock > 0 {
+			info.Remaining = max(p.Stock-issued[p.Code], 0)
+		}
+		if (p.Stock == 0 || info.Remaining > 0) && total > 0 {
+			info.Probability = float64(p.Weight) / float64(total)
+		}
+		output.Prizes = append(output.Prizes, info)
+	}
+	// 2. 今天的抽奖结果
+	ld := query.LuckyDraw
+	do := ld.WithContext(ctx)
+	if primary {
+		do = do.WriteDB()
+	}
+	now := time.Now()
+	today, err := do.Where(ld.UserID.Eq(userID), ld.DrawDate.Eq(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()))).First()
+	switch {
+	case err == nil:
+		output.Today = toInfo(lang, today)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		logging.Ctx(ctx).Error("query lucky_draws error", zap.Error(err))
+		return nil, err
+	}
+	if cfg.Enabled && output.Today == nil && total > 0 {
+		checked, err := checkin.IsCheckedToday(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		output.CanDraw = checked
+	}
+	// 3. 剩余的补签卡
+	output.RetroCard, err = checkin.RetroCards(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// List 分页查询用户的抽奖记录，按时间倒序
+func List(ctx context.Context, input *model.DrawListInput) (*model.DrawListOutput, error) {
+	ld := query.LuckyDraw
+	do := ld.WithContext(ctx)
+	if dao.ReadPrimary(ctx, input.UserID) {
+		do = do.WriteDB()
+	}
+	rows, total, err := do.Where(ld.UserID.Eq(input.UserID)).Order(ld.ID.Desc()).FindByPage(input.Offset, input.Limit)
+	if err != nil {
+		logging.Ctx(ctx).Error("query lucky_draws error", zap.Error(err))
+		return nil, err
+	}
+	lang := i18n.FromContext(ctx)
+	list := make([]*model.DrawInfo, 0, len(rows))
+	for _, row := range rows {
+		list = append(list, toInfo(lang, row))
+	}
+	return &model.DrawListOutput{Total: total, List: list}, nil
+}
+
+func toInfo(lang string, row *model.LuckyDraw) *model.DrawInfo {
+	return &model.DrawInfo{
+		ID:              row.ID,
+		DrawDate:        row.DrawDate,
+		PrizeCode:       row.PrizeCode,
+		PrizeName:       i18n.T(lang, fmt.Sprintf(prizeNameKey, row.PrizeCode)),
+		PrizeType:       model.DrawPrizeType(row.PrizeType),
+		Amount:          row.Amount,
+		CouponCode:      row.CouponCode,
+		CouponExpiresAt: row.CouponExpiresAt,
+		ServerSeed:      row.ServerSeed,
+		Nonce:           row.Nonce,
+		Roll:            row.Roll,
+		TotalWeight:     row.TotalWeight,
+		CreatedAt:       row.CreatedAt,
+	}
+}
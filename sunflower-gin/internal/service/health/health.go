@@ -0,0 +1,86 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/task"
+)
+
+// 依赖检查
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	checkTimeout = 2 * time.Second // 单个依赖检查的超时时间
+)
+
+const (
+	CheckMySQL = "mysql"
+	CheckRedis = "redis"
+	CheckCron  = "cron"
+)
+
+var (
+	ErrNotInitialized = errors.New("not initialized")
+	ErrCronStopped    = errors.New("cron scheduler is not running")
+)
+
+// checker 依赖检查函数
+type checker func(ctx context.Context) error
+
+var checkers = map[string]checker{
+	CheckMySQL: checkMySQL,
+	CheckRedis: checkRedis,
+	CheckCron:  checkCron,
+}
+
+// Check 检查指定的依赖，返回每个依赖的检查结果
+func Check(ctx context.Context, names ...string) map[string]*model.CheckResult {
+	results := make(map[string]*model.CheckResult, len(names))
+	for _, name := range names {
+		fn, ok := checkers[name]
+		if !ok {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+		start := time.Now()
+		err := fn(ctx)
+		cancel()
+		results[name] = &model.CheckResult{
+			Up:      err == nil,
+			Latency: time.Since(start),
+			Err:     err,
+		}
+	}
+	return results
+}
+
+func checkMySQL(ctx context.Context) error {
+	if dao.DB == nil {
+		return ErrNotInitialized
+	}
+	sqlDB, err := dao.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func checkRedis(ctx context.Context) error {
+	if dao.RedisClient == nil {
+		return ErrNotInitialized
+	}
+	return dao.RedisClient.Ping(ctx).Err()
+}
+
+func checkCron(_ context.Context) error {
+	if !task.Running() {
+		return ErrCronStopped
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+package mission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sunflower-gin/internal/cache"
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/ledger"
+	"sunflower-gin/internal/metrics"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/progress"
+	"sunflower-gin/pkg/dateutil"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+	"sunflower-gin/pkg/tracing"
+
+	"go.uber.org/zap"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 任务
+// 任务的定义在配置文件 mission.definitions 中，其它服务通过内部接口上报事件，累计达到目标次数后完成任务，用户手动领取奖励
+// 进度按周期记录，每日任务的周期为日期，每周任务为 ISO 周，进入新的周期后进度自动从 0 开始，
+// 上一个周期完成但没有领取的奖励作废，定时任务只负责清理已经结束的周期
+
+const (
+	onceKey        = "once"
+	claimDescKey   = "points.desc.mission" // 任务奖励：%s
+	cleanupBatch   = 1000                  // 清理时每次删除的行数
+	missionNameKey = "mission.%s"          // 任务名称的多语言 key
+)
+
+var (
+	ErrNotFound     = i18n.NewError("error.mission.not_found")     // 任务不存在
+	ErrNotCompleted = i18n.NewError("error.mission.not_completed") // 任务还没有完成
+	ErrClaimed      = i18n.NewError("error.mission.claimed")       // 奖励已经领取过了
+)
+
+// Report 处理其它服务上报的事件，累加当前周期内所有监听这个事件的任务进度
+// 同一个事件ID只处理一次，重复上报时返回 Duplicate
+func Report(ctx context.Context, input *model.MissionEventInput) (*model.MissionEventOutput, error) {
+	ctx, span := tracing.Start(ctx, "mission.Report")
+	defer span.End()
+	var rules []conf.MissionRule
+	for _, rule := range conf.Get().Mission.Definitions {
+		if rule.Event == input.Event {
+			rules = append(rules, rule)
+		}
+	}
+	now := time.Now()
+	output := &model.MissionEventOutput{}
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		// 1. 记录事件，事件ID已经存在时不再处理
+		res := tx.MissionEvent.WithContext(ctx).UnderlyingDB().
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.MissionEvent{EventID: input.EventID, UserID: input.UserID, Event: input.Event, Count: input.Count})
+		if res.Error != nil {
+			logging.Ctx(ctx).Error("create mission_events error", zap.Error(res.Error))
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			output.Duplicate = true
+			return nil
+		}
+		// 2. 累加每个任务在当前周期的进度
+		for _, rule := range rules {
+			completed, err := advance(ctx, tx, input.UserID, &rule, input.Count, now)
+			if err != nil {
+				return err
+			}
+			if completed {
+				output.Completed = append(output.Completed, rule.Code)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if output.Duplicate {
+		metrics.MissionEventsTotal.WithLabelValues("duplicate").Inc()
+		return output, nil
+	}
+	metrics.MissionEventsTotal.WithLabelValues(metrics.StatusOK).Inc()
+	if len(rules) > 0 {
+		dao.MarkWritten(ctx, input.UserID)
+	}
+	return output, nil
+}
+
+// advance 累加一个任务的进度，返回这次是否完成了任务
+func advance(ctx context.Context, tx *query.Query, userID int64, rule *conf.MissionRule, count int64, now time.Time) (bool, error) {
+	um := tx.UserMission
+	key := periodKey(rule.Period, now)
+	if err := um.WithContext(ctx).UnderlyingDB().
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.UserMission{UserID: userID, Code: rule.Code, PeriodKey: key}).Error; err != nil {
+		logging.Ctx(ctx).Error("create user_missions error", zap.Error(err))
+		return false, err
+	}
+	row, err := um.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(um.UserID.Eq(userID), um.Code.Eq(rule.Code), um.PeriodKey.Eq(key)).
+		First()
+	if err != nil {
+		logging.Ctx(ctx).Error("query user_missions error", zap.Error(err))
+		return false, err
+	}
+	if row.CompletedAt != nil {
+		return false, nil
+	}
+	progress := min(row.Progress+count, rule.Target)
+	updates := []field.AssignExpr{um.Progress.Value(progress)}
+	completed := progress >= rule.Target
+	if completed {
+		updates = append(updates, um.CompletedAt.Value(now))
+	}
+	if _, err := um.WithContext(ctx).Where(um.ID.Eq(row.ID)).UpdateSimple(updates...); err != nil {
+		logging.Ctx(ctx).Error("update user_missions error", zap.Error(err))
+		return false, err
+	}
+	return completed, nil
+}
+
+// List 所有任务和用户在当前周期的进度，按配置的顺序排列
+func List(ctx context.Context, userID int64) ([]*model.MissionInfo, error) {
+	rules := conf.Get().Mission.Definitions
+	now := time.Now()
+	um := query.UserMission
+	do := um.WithContext(ctx)
+	if dao.ReadPrimary(ctx, userID) {
+		do = do.WriteDB()
+	}
+	keys := make([]string, 0, 3)
+	for _, period := range []string{conf.MissionPeriodDaily, conf.MissionPeriodWeekly, conf.MissionPeriodOnce} {
+		keys = append(keys, periodKey(period, now))
+	}
+	rows, err := do.Where(um.UserID.Eq(userID), um.PeriodKey.In(keys...)).Find()
+	if err != nil {
+		logging.Ctx(ctx).Error("query user_missions error", zap.Error(err))
+		return nil, err
+	}
+	rowMap := make(map[string]*model.UserMission, len(rows))
+	for _, v := range rows {
+		rowMap[v.Code+":"+v.PeriodKey] = v
+	}
+	lang := i18n.FromContext(ctx)
+	list := make([]*model.MissionInfo, 0, len(rules))
+	for _, rule := range rules {
+		info := &model.MissionInfo{
+			Code:    rule.Code,
+			Name:    i18n.T(lang, fmt.Sprintf(missionNameKey, rule.Code)),
+			Period:  rule.Period,
+			Target:  rule.Target,
+			Points:  rule.Points,
+			ResetAt: resetAt(rule.Period, now),
+		}
+		if row, ok := rowMap[rule.Code+":"+periodKey(rule.Period, now)]; ok {
+			info.Progress, info.CompletedAt, info.ClaimedAt = row.Progress, row.CompletedAt, row.ClaimedAt
+		}
+		list = append(list, info)
+	}
+	return list, nil
+}
+
+// Claim 领取当前周期已经完成的任务奖励，积分从系统发放账户转入用户钱包
+func Claim(ctx context.Context, userID int64, code string) (*model.MissionClaimOutput, error) {
+	ctx, span := tracing.Start(ctx, "mission.Claim")
+	defer span.End()
+	var rule *conf.MissionRule
+	for _, v := range conf.Get().Mission.Definitions {
+		if v.Code == code {
+			rule = &v
+			break
+		}
+	}
+	if rule == nil {
+		return nil, ErrNotFound
+	}
+	now := time.Now()
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		um := tx.UserMission
+		row, err := um.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(um.UserID.Eq(userID), um.Code.Eq(code), um.PeriodKey.Eq(periodKey(rule.Period, now))).
+			First()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotCompleted
+		}
+		if err != nil {
+			logging.Ctx(ctx).Error("query user_missions error", zap.Error(err))
+			return err
+		}
+		if row.CompletedAt == nil {
+			return ErrNotCompleted
+		}
+		if row.ClaimedAt != nil {
+			return ErrClaimed
+		}
+		if _, err := ledger.Post(ctx, tx, &model.LedgerEntryInput{
+			Type:     model.PointsTransactionTypeMission,
+			DescKey:  claimDescKey,
+			DescArgs: []any{i18n.T(i18n.DefaultLang, fmt.Sprintf(missionNameKey, code))},
+			Ext:      model.TransactionExt{Mission: code},
+			Lines: []*model.LedgerLine{
+				{AccountType: model.LedgerAccountTypeIssuance, Amount: -rule.Points},
+				{AccountType: model.LedgerAccountTypeUserWallet, OwnerID: userID, Amount: rule.Points},
+			},
+		}); err != nil {
+			return err
+		}
+		if _, err := um.WithContext(ctx).Where(um.ID.Eq(row.ID)).UpdateSimple(um.ClaimedAt.Value(now)); err != nil {
+			logging.Ctx(ctx).Error("update user_missions error", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	dao.MarkWritten(ctx, userID)
+	cache.Delete(ctx, cache.PointsSummaryKey(userID))
+	metrics.PointsIssuedTotal.WithLabelValues(model.PointsTransactionTypeMission.String()).Add(float64(rule.Points))
+	metrics.MissionClaimsTotal.WithLabelValues(code).Inc()
+	// 累计获得的积分增加，重新计算成就和等级，失败不影响领取结果
+	progress.OnPointsEarned(ctx, userID)
+	return &model.MissionClaimOutput{Code: code, Points: rule.Points}, nil
+}
+
+// Cleanup 删除结束超过 retention 的每日、每周任务进度和超过 eventRetention 的事件记录，返回删除的行数
+// 进度按周期判断是否结束，不看更新时间；一次性任务的进度一直保留，事件记录删除后同一个事件ID可以再次上报
+func Cleanup(ctx context.Context, retention, eventRetention time.Duration) (int64, error) {
+	ctx, span := tracing.Start(ctx, "mission.Cleanup")
+	defer span.End()
+	// 1. before 所在周期之前的周期都已经结束超过 retention，每周任务的周期包含 W，每日任务的周期都是数字
+	before := time.Now().Add(-retention)
+	um := query.UserMission
+	var total int64
+	for _, cond := range [][]gen.Condition{
+		{um.PeriodKey.NotLike("%W%"), um.PeriodKey.Lt(periodKey(conf.MissionPeriodDaily, before))},
+		{um.PeriodKey.Like("%W%"), um.PeriodKey.Lt(periodKey(conf.MissionPeriodWeekly, before))},
+	} {
+		deleted, err := deleteAll(ctx, func() (gen.ResultInfo, error) {
+			return um.WithContext(ctx).Where(cond...).Limit(cleanupBatch).Delete()
+		})
+		total += deleted
+		if err != nil {
+			logging.Ctx(ctx).Error("delete user_missions error", zap.Error(err))
+			return total, err
+		}
+	}
+	// 2. 事件记录只用于去重，保留的时间要比上报方重试的时间长
+	me := query.MissionEvent
+	eventBefore := time.Now().Add(-eventRetention)
+	deleted, err := deleteAll(ctx, func() (gen.ResultInfo, error) {
+		return me.WithContext(ctx).Where(me.CreatedAt.Lt(eventBefore)).Limit(cleanupBatch).Delete()
+	})
+	total += deleted
+	if err != nil {
+		logging.Ctx(ctx).Error("delete mission_events error", zap.Error(err))
+	}
+	return total, err
+}
+
+// deleteAll 分批删除，直到一批删除的行数不足 cleanupBatch，返回删除的总行数
+func deleteAll(ctx context.Context, del func() (gen.ResultInfo, error)) (int64, error) {
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		info, err := del()
+		if err != nil {
+			return total, err
+		}
+		total += info.RowsAffected
+		if info.RowsAffected < cleanupBatch {
+			return total, nil
+		}
+	}
+}
+
+// periodKey 任务在 now 所在的周期
+func periodKey(period string, now time.Time) string {
+	switch period {
+	case conf.MissionPeriodDaily:
+		return now.Format("20060102")
+	case conf.MissionPeriodWeekly:
+		year, week := now.ISOWeek()
+		return fmt.Sprintf("%dW%02d", year, week)
+	default:
+		return onceKey
+	}
+}
+
+// resetAt 下一个周期开始的时间，一次性任务返回 nil
+func resetAt(period string, now time.Time) *time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var t time.Time
+	switch period {
+	case conf.MissionPeriodDaily:
+		t = today.AddDate(0, 0, 1)
+	case conf.MissionPeriodWeekly:
+		t = today.AddDate(0, 0, 8-dateutil.ISOWeekday(now))
+	default:
+		return nil
+	}
+	return &t
+}
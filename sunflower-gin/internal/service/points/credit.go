@@ -0,0 +1,104 @@
+package points
+
+import (
+	"context"
+	"errors"
+
+	"sunflower-gin/internal/cache"
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/ledger"
+	"sunflower-gin/internal/metrics"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/progress"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+	"sunflower-gin/pkg/tracing"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+)
+
+// 其它服务通过内部接口给用户发放积分，如商城订单返积分
+
+const creditDescKey = "points.desc.credit" // %s，调用方传入的发放原因
+
+var (
+	ErrCreditTooLarge = i18n.NewError("error.points.credit_too_large") // 超过单次发放积分的上限
+	ErrCreditConflict = i18n.NewError("error.points.credit_conflict")  // 请求ID已经用于另一笔发放
+)
+
+// Credit 给用户发放积分，积分从系统发放账户转入用户钱包
+// 同一个 API key 重复提交同一个请求ID只发放一次，重复提交时返回第一次发放的记录，
+// 用户、积分或原因与第一次不一致时返回 ErrCreditConflict，避免调用方复用请求ID时静默丢掉一笔发放
+func Credit(ctx context.Context, input *model.PointsCreditInput) (*model.PointsCreditOutput, error) {
+	ctx, span := tracing.Start(ctx, "points.Credit")
+	defer span.End()
+	if limit := conf.Get().Internal.MaxCreditPoints; limit > 0 && input.Points > limit {
+		return nil, ErrCreditTooLarge
+	}
+	output := &model.PointsCreditOutput{Points: input.Points}
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		// 1. 记录请求，请求ID已经存在时不再发放
+		row := &model.PointsCredit{
+			APIKeyID:  input.APIKeyID,
+			RequestID: input.RequestID,
+			UserID:    input.UserID,
+			Points:    input.Points,
+			Reason:    input.Reason,
+		}
+		res := tx.PointsCredit.WithContext(ctx).UnderlyingDB().
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(row)
+		if res.Error != nil {
+			logging.Ctx(ctx).Error("create points_credits error", zap.Error(res.Error))
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			pc := tx.PointsCredit
+			existing, err := pc.WithContext(ctx).
+				Where(pc.APIKeyID.Eq(input.APIKeyID), pc.RequestID.Eq(input.RequestID)).
+				First()
+			if err != nil {
+				logging.Ctx(ctx).Error("query points_credits error", zap.Error(err))
+				return err
+			}
+			if existing.UserID != input.UserID || existing.Points != input.Points || existing.Reason != input.Reason {
+				return ErrCreditConflict
+			}
+			output.Duplicate, output.CreditID, output.Points = true, existing.ID, existing.Points
+			return nil
+		}
+		output.CreditID = row.ID
+		// 2. 记账
+		_, err := ledger.Post(ctx, tx, &model.LedgerEntryInput{
+			Type:     model.PointsTransactionTypeCredit,
+			DescKey:  creditDescKey,
+			DescArgs: []any{input.Reason},
+			Ext:      model.TransactionExt{CreditID: row.ID},
+			Lines: []*model.LedgerLine{
+				{AccountType: model.LedgerAccountTypeIssuance, Amount: -input.Points},
+				{AccountType: model.LedgerAccountTypeUserWallet, OwnerID: input.UserID, Amount: input.Points},
+			},
+		})
+		return err
+	})
+	if errors.Is(err, ErrCreditConflict) {
+		metrics.PointsCreditsTotal.WithLabelValues("conflict").Inc()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if output.Duplicate {
+		metrics.PointsCreditsTotal.WithLabelValues("duplicate").Inc()
+		return output, nil
+	}
+	dao.MarkWritten(ctx, input.UserID)
+	cache.Delete(ctx, cache.PointsSummaryKey(input.UserID))
+	metrics.PointsIssuedTotal.WithLabelValues(model.PointsTransactionTypeCredit.String()).Add(float64(input.Points))
+	metrics.PointsCreditsTotal.WithLabelValues(metrics.StatusOK).Inc()
+	// 累计获得的积分增加，重新计算成就和等级，失败不影响发放结果
+	progress.OnPointsEarned(ctx, input.UserID)
+	return output, nil
+}
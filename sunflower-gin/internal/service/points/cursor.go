@@ -0,0 +1,39 @@
+package points
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// 分页游标，记录上一页最后一条数据的 (created_at, id)，积分记录和转赠记录共用
+// 对客户端不透明，base64 编码后返回，客户端原样传回即可
+
+// Cursor 解码后的分页游标
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int64     `json:"id"`
+}
+
+// EncodeCursor 按上一页最后一条数据生成游标
+func EncodeCursor(createdAt time.Time, id int64) string {
+	b, _ := json.Marshal(&Cursor{CreatedAt: createdAt, ID: id})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor 解析客户端传回的游标，格式错误时返回 error，调用方转换为 ErrInvalidCursor
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	if c.CreatedAt.IsZero() || c.ID <= 0 {
+		return nil, errors.New("incomplete cursor")
+	}
+	return &c, nil
+}
@@ -0,0 +1,82 @@
+package points
+
+import (
+	"context"
+	"time"
+
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+
+	"go.uber.org/zap"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+// 导出积分记录
+// 按 (created_at, id) 正序分批查询，每批处理完再查下一批，内存中只保留一批数据
+
+const exportBatchSize = 500
+
+// Export 按时间正序遍历用户的积分记录，每条记录调用一次 yield，yield 返回错误时停止遍历
+// 导出是大范围扫描，固定走从库
+func Export(ctx context.Context, input *model.ExportInput, yield func(row *model.ExportRow) error) error {
+	t := query.UserPointsTransaction
+	conds := []gen.Condition{t.UserID.Eq(input.UserID)}
+	if !input.StartTime.IsZero() {
+		conds = append(conds, t.CreatedAt.Gte(input.StartTime))
+	}
+	if !input.EndTime.IsZero() {
+		conds = append(conds, t.CreatedAt.Lt(input.EndTime))
+	}
+	lang := i18n.FromContext(ctx)
+	var last *model.UserPointsTransaction
+	for {
+		// 服务退出或客户端断开时不再继续查询
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		do := t.WithContext(ctx).Where(conds...)
+		if last != nil {
+			// (created_at, id) > (last.CreatedAt, last.ID)
+			do = do.Where(field.Or(
+				t.CreatedAt.Gt(last.CreatedAt),
+				field.And(t.CreatedAt.Eq(last.CreatedAt), t.ID.Gt(last.ID)),
+			))
+		}
+		records, err := do.Order(t.CreatedAt, t.ID).Limit(exportBatchSize).Find()
+		if err != nil {
+			logging.Ctx(ctx).Error("export user points transaction error", zap.Error(err))
+			return err
+		}
+		for _, v := range records {
+			err := yield(&model.ExportRow{
+				TransactionTime: v.CreatedAt,
+				TransactionType: v.TransactionType,
+				PointsChange:    v.PointsChange,
+				CurrentBalance:  v.CurrentBalance,
+				Description:     renderDescription(ctx, lang, v),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(records) < exportBatchSize {
+			return nil
+		}
+		last = records[len(records)-1]
+	}
+}
+
+// ExportFilename 导出文件名，如 points-20250101-20250131.csv
+func ExportFilename(input *model.ExportInput, ext string) string {
+	name := "points"
+	if !input.StartTime.IsZero() {
+		name += "-" + input.StartTime.Format("20060102")
+	}
+	if !input.EndTime.IsZero() {
+		name += "-" + input.EndTime.Add(-time.Nanosecond).Format("20060102")
+	}
+	return name + "." + ext
+}
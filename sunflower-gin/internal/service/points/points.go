@@ -3,26 +3,55 @@ package points
 import (
 	"context"
 	"errors"
+	"sunflower-gin/internal/cache"
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
 	"sunflower-gin/internal/dao/query"
 	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
 	"time"
 
 	"go.uber.org/zap"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
 	"gorm.io/gorm"
 )
 
-// Summary 查询用户积分信息
+// 积分记录的收支过滤
+const (
+	SignEarn  = "earn"  // 收入
+	SignSpend = "spend" // 支出
+)
+
+var (
+	ErrInvalidCursor = i18n.NewError("error.points.invalid_cursor") // 无效的分页游标
+)
+
+// Summary 查询用户积分信息，优先读缓存，积分变动时由签到和补签逻辑删除缓存
 func Summary(ctx context.Context, userID int64) (*model.SummaryOutput, error) {
+	return cache.GetOrLoad(ctx, cache.NamePointsSummary, cache.PointsSummaryKey(userID), conf.Get().Cache.PointsSummaryTTL,
+		func(ctx context.Context) (*model.SummaryOutput, error) {
+			return loadSummary(ctx, userID)
+		})
+}
+
+// loadSummary 从数据库查询用户积分信息
+func loadSummary(ctx context.Context, userID int64) (*model.SummaryOutput, error) {
 	output := &model.SummaryOutput{}
-	// 1. 从数据库中查询用户积分信息
-	upInst, err := query.UserPoint.WithContext(ctx).
+	// 1. 从数据库中查询用户积分信息，用户刚写入过数据时走主库
+	do := query.UserPoint.WithContext(ctx)
+	if dao.ReadPrimary(ctx, userID) {
+		do = do.WriteDB()
+	}
+	upInst, err := do.
 		Where(query.UserPoint.UserID.Eq(userID)).
 		First()
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) { // 如果是新用户还没有积分信息，则直接返回
 			return output, nil
 		}
-		zap.L().Error("query user point error", zap.Error(err))
+		logging.Ctx(ctx).Error("query user point error", zap.Error(err))
 		return nil, err
 	}
 	// 2. 将数据封装到结构体中返回
@@ -31,33 +60,97 @@ func Summary(ctx context.Context, userID int64) (*model.SummaryOutput, error) {
 }
 
 // Records 查询用户积分记录
+// 按 (created_at, id) 倒序做游标分页，翻页过程中有新记录写入也不会出现重复数据
 func Records(ctx context.Context, input *model.RecordsInput) (*model.RecordsOutput, error) {
-	// 1. 从数据库中分页查询用户积分记录
-	var records []*model.UserPointsTransaction
-	total, err := query.UserPointsTransaction.WithContext(ctx).
-		Where(query.UserPointsTransaction.UserID.Eq(input.UserID)).
-		Order(query.UserPointsTransaction.CreatedAt.Desc()).
-		ScanByPage(&records, input.Offset, input.Limit)
+	t := query.UserPointsTransaction
+	// 1. 组装过滤条件
+	conds := []gen.Condition{t.UserID.Eq(input.UserID)}
+	if len(input.Types) > 0 {
+		conds = append(conds, t.TransactionType.In(input.Types...))
+	}
+	switch input.Sign {
+	case SignEarn:
+		conds = append(conds, t.PointsChange.Gt(0))
+	case SignSpend:
+		conds = append(conds, t.PointsChange.Lt(0))
+	}
+	if !input.StartTime.IsZero() {
+		conds = append(conds, t.CreatedAt.Gte(input.StartTime))
+	}
+	if !input.EndTime.IsZero() {
+		conds = append(conds, t.CreatedAt.Lt(input.EndTime))
+	}
+	// 用户刚写入过数据时走主库，每次查询都新建一个 do，避免 Count 和 Find 互相影响
+	primary := dao.ReadPrimary(ctx, input.UserID)
+	newDo := func() query.IUserPointsTransactionDo {
+		do := t.WithContext(ctx)
+		if primary {
+			do = do.WriteDB()
+		}
+		return do.Where(conds...)
+	}
+
+	output := &model.RecordsOutput{}
+	// 2. 总数需要额外扫描所有符合条件的记录，只在需要时统计
+	if input.WithTotal {
+		total, err := newDo().Count()
+		if err != nil {
+			logging.Ctx(ctx).Error("count user points transaction error", zap.Error(err))
+			return nil, err
+		}
+		output.Total = &total
+	}
+	// 3. 查询一页数据，多查一条用来判断是否还有下一页
+	do := newDo()
+	if input.Cursor != "" {
+		cursor, err := DecodeCursor(input.Cursor)
+		if err != nil {
+			logging.Ctx(ctx).Warn("decode records cursor error", zap.String("cursor", input.Cursor), zap.Error(err))
+			return nil, ErrInvalidCursor
+		}
+		// (created_at, id) < (cursor.CreatedAt, cursor.ID)
+		do = do.Where(field.Or(
+			t.CreatedAt.Lt(cursor.CreatedAt),
+			field.And(t.CreatedAt.Eq(cursor.CreatedAt), t.ID.Lt(cursor.ID)),
+		))
+	} else if input.Offset > 0 {
+		do = do.Offset(input.Offset)
+	}
+	records, err := do.Order(t.CreatedAt.Desc(), t.ID.Desc()).Limit(input.Limit + 1).Find()
 	if err != nil {
-		zap.L().Error("query user points transaction error", zap.Error(err))
+		logging.Ctx(ctx).Error("query user points transaction error", zap.Error(err))
 		return nil, err
 	}
-	// 2. 格式化数据
+	if len(records) > input.Limit {
+		records = records[:input.Limit]
+		output.HasMore = true
+		output.NextCursor = EncodeCursor(records[len(records)-1].CreatedAt, records[len(records)-1].ID)
+	}
+	// 4. 格式化数据
+	lang := i18n.FromContext(ctx)
 	list := make([]*model.RecordInfo, 0, len(records))
 	for _, v := range records {
 		list = append(list, &model.RecordInfo{
 			PointsChange:    v.PointsChange,
 			TransactionType: v.TransactionType,
-			Description:     v.Description,
+			Description:     renderDescription(ctx, lang, v),
 			TransactionTime: v.CreatedAt.Format(time.DateTime),
 		})
 	}
-	hasMore := len(records) == input.Limit &&
-		int(total) > input.Offset+input.Limit
-	// 3. 返回数据
-	return &model.RecordsOutput{
-		Total:   total,
-		HasMore: hasMore,
-		List:    list,
-	}, nil
+	output.List = list
+	return output, nil
+}
+
+// renderDescription 按读者语言渲染积分记录的描述信息
+// ExtJSON 中记录了多语言 key 的按 key 翻译，历史数据直接返回入库时的描述
+func renderDescription(ctx context.Context, lang string, record *model.UserPointsTransaction) string {
+	ext, err := model.ParseTransactionExt(record.ExtJSON)
+	if err != nil {
+		logging.Ctx(ctx).Warn("parse transaction ext_json error", zap.Int64("id", record.ID), zap.Error(err))
+		return record.Description
+	}
+	if ext.DescKey == "" {
+		return record.Description
+	}
+	return i18n.T(lang, ext.DescKey, ext.DescArgs...)
 }
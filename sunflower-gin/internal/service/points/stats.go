@@ -0,0 +1,196 @@
+package points
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 积分统计，按交易类型和时间周期从 user_points_transactions 表实时聚合
+
+// 时间序列的统计粒度
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+const (
+	maxSeriesPoints = 366 // 时间序列最多返回的点数
+
+	earnedExpr = "COALESCE(SUM(CASE WHEN points_change > 0 THEN points_change ELSE 0 END), 0) AS earned"
+	spentExpr  = "COALESCE(SUM(CASE WHEN points_change < 0 THEN -points_change ELSE 0 END), 0) AS spent"
+)
+
+// 各统计粒度的周期在 SQL 中的表达式，与 periodLabel 的格式保持一致，周以周一为起点
+var periodExprMap = map[string]string{
+	GranularityDay:   "DATE_FORMAT(created_at, '%Y-%m-%d')",
+	GranularityWeek:  "DATE_FORMAT(DATE_SUB(created_at, INTERVAL WEEKDAY(created_at) DAY), '%Y-%m-%d')",
+	GranularityMonth: "DATE_FORMAT(created_at, '%Y-%m')",
+}
+
+// 没有指定时间范围时，默认统计最近多少个周期
+var defaultPeriodsMap = map[string]int{
+	GranularityDay:   30,
+	GranularityWeek:  12,
+	GranularityMonth: 12,
+}
+
+var (
+	ErrStatsRangeTooLarge = i18n.NewError("error.points.stats_range_too_large") // 统计的时间范围过大
+)
+
+// Stats 查询用户积分统计：当前余额、累计获得和消耗、按交易类型汇总以及按时间周期汇总的时间序列
+func Stats(ctx context.Context, input *model.StatsInput) (*model.StatsOutput, error) {
+	granularity := input.Granularity
+	if _, ok := periodExprMap[granularity]; !ok {
+		granularity = GranularityDay
+	}
+	start, end := statsRange(granularity, input.StartTime, input.EndTime)
+	if countPeriods(granularity, start, end) > maxSeriesPoints {
+		return nil, ErrStatsRangeTooLarge
+	}
+	// 用户刚写入过数据时走主库
+	primary := dao.ReadPrimary(ctx, input.UserID)
+	t := query.UserPointsTransaction
+	txDB := func() *gorm.DB {
+		do := t.WithContext(ctx)
+		if primary {
+			do = do.WriteDB()
+		}
+		return do.Where(t.UserID.Eq(input.UserID)).UnderlyingDB()
+	}
+
+	// 1. 当前余额和累计获得
+	upDo := query.UserPoint.WithContext(ctx)
+	if primary {
+		upDo = upDo.WriteDB()
+	}
+	upInst, err := upDo.Where(query.UserPoint.UserID.Eq(input.UserID)).First()
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logging.Ctx(ctx).Error("query user point error", zap.Error(err))
+			return nil, err
+		}
+		upInst = &model.UserPoint{UserID: input.UserID} // 新用户还没有积分信息
+	}
+	output := &model.StatsOutput{
+		Balance:        upInst.Points,
+		LifetimeEarned: upInst.PointsTotal,
+		Granularity:    granularity,
+		ByType:         make([]*model.TypeStats, 0),
+	}
+
+	// 2. 按交易类型汇总，累计消耗由各类型的消耗相加得到
+	if err := txDB().
+		Select("transaction_type, " + earnedExpr + ", " + spentExpr + ", COUNT(*) AS count").
+		Group("transaction_type").
+		Order("transaction_type").
+		Scan(&output.ByType).Error; err != nil {
+		logging.Ctx(ctx).Error("stats by type error", zap.Error(err))
+		return nil, err
+	}
+	for _, v := range output.ByType {
+		output.LifetimeSpent += v.Spent
+	}
+
+	// 3. 按时间周期汇总，没有记录的周期补 0，方便前端直接画图
+	var rows []*model.SeriesPoint
+	if err := txDB().
+		Select(periodExprMap[granularity]+" AS period, "+earnedExpr+", "+spentExpr).
+		Where("created_at >= ? AND created_at < ?", start, end).
+		Group("period").
+		Scan(&rows).Error; err != nil {
+		logging.Ctx(ctx).Error("stats series error", zap.Error(err))
+		return nil, err
+	}
+	rowMap := make(map[string]*model.SeriesPoint, len(rows))
+	for _, v := range rows {
+		rowMap[v.Period] = v
+	}
+	for p := start; p.Before(end); p = nextPeriod(granularity, p) {
+		label := periodLabel(granularity, p)
+		point, ok := rowMap[label]
+		if !ok {
+			point = &model.SeriesPoint{Period: label}
+		}
+		output.Series = append(output.Series, point)
+	}
+	return output, nil
+}
+
+// statsRange 计算时间序列的统计范围 [start, end)，start 对齐到周期的起点
+// 没有指定结束时间时统计到当前周期，没有指定开始时间时往前取默认的周期数
+func statsRange(granularity string, start, end time.Time) (time.Time, time.Time) {
+	if end.IsZero() {
+		end = nextPeriod(granularity, periodStart(granularity, time.Now()))
+	}
+	if start.IsZero() {
+		start = periodStart(granularity, end.Add(-time.Nanosecond))
+		for range defaultPeriodsMap[granularity] - 1 {
+			start = prevPeriod(granularity, start)
+		}
+	}
+	return periodStart(granularity, start), end
+}
+
+func countPeriods(granularity string, start, end time.Time) int {
+	n := 0
+	for p := start; p.Before(end) && n <= maxSeriesPoints; p = nextPeriod(granularity, p) {
+		n++
+	}
+	return n
+}
+
+// periodStart 时间所在周期的起点
+func periodStart(granularity string, t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch granularity {
+	case GranularityWeek:
+		offset := (int(day.Weekday()) + 6) % 7 // 周一为 0
+		return day.AddDate(0, 0, -offset)
+	case GranularityMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+func nextPeriod(granularity string, t time.Time) time.Time {
+	switch granularity {
+	case GranularityWeek:
+		return t.AddDate(0, 0, 7)
+	case GranularityMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+func prevPeriod(granularity string, t time.Time) time.Time {
+	switch granularity {
+	case GranularityWeek:
+		return t.AddDate(0, 0, -7)
+	case GranularityMonth:
+		return t.AddDate(0, -1, 0)
+	default:
+		return t.AddDate(0, 0, -1)
+	}
+}
+
+// periodLabel 周期的名称，与 periodExprMap 中 SQL 的输出格式一致
+func periodLabel(granularity string, t time.Time) string {
+	if granularity == GranularityMonth {
+		return t.Format("2006-01")
+	}
+	return t.Format(time.DateOnly)
+}
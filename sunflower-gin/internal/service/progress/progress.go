@@ -0,0 +1,28 @@
+package progress
+
+import (
+	"context"
+
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/achievement"
+	"sunflower-gin/internal/service/tier"
+	"sunflower-gin/pkg/logging"
+
+	"go.uber.org/zap"
+)
+
+// 用户获得积分后的成长进度：成就和等级都依赖累计获得的积分，由发放积分的业务在事务提交后调用
+
+// OnPointsEarned 用户累计获得的积分增加后重新计算成就和等级，失败只记录日志，不影响发放积分的业务
+// 用户之间转赠的积分不算获得，不需要调用
+func OnPointsEarned(ctx context.Context, userID int64) {
+	if _, err := achievement.Evaluate(ctx, &model.AchievementEvent{
+		UserID: userID,
+		Type:   model.AchievementEventPointsEarned,
+	}); err != nil {
+		logging.Ctx(ctx).Error("achievement.Evaluate error", zap.Int64("user_id", userID), zap.Error(err))
+	}
+	if _, err := tier.Evaluate(ctx, userID, 0); err != nil {
+		logging.Ctx(ctx).Error("tier.Evaluate error", zap.Int64("user_id", userID), zap.Error(err))
+	}
+}
@@ -0,0 +1,278 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/ledger"
+	"sunflower-gin/internal/metrics"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+	"sunflower-gin/pkg/tracing"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 积分对账
+// 以复式记账的记账明细 ledger_postings 为准，检查每个用户：
+// 1. 每条流水的 CurrentBalance 是否等于它之前（含自身）所有流水 PointsChange 的累加，即余额链是否连续
+// 2. 用户钱包 ledger_accounts.balance 是否等于钱包所有记账明细的累加
+// 3. user_points 表的余额是否等于钱包所有记账明细的累加
+// 还没有钱包的用户没有记过账，检查 user_points 表的余额是否等于所有流水 PointsChange 的累加
+// 修复时不改动历史流水和分录：钱包余额改为记账明细的累加，user_points 与记账明细的差额
+// 通过 ledger.Post 记一笔校正分录；没有钱包的用户追加一条校正流水，第一次记账时钱包按 user_points 的余额记期初分录，
+// 所以不通过记账修复；断裂的余额链只报告不修复
+
+const (
+	batchSize          = 500 // 分批查询的大小
+	maxChainBreaks     = 10  // 每个用户最多记录多少处余额链断裂
+	maxDiscrepancyList = 1000
+
+	correctionDescKey = "points.desc.correction" // 对账校正
+)
+
+// Run 对账，UserIDs 为空时按 user_points 表遍历所有用户
+func Run(ctx context.Context, input *model.ReconcileInput) (*model.ReconcileOutput, error) {
+	ctx, span := tracing.Start(ctx, "reconcile.Run")
+	defer span.End()
+	output := &model.ReconcileOutput{}
+	check := func(userID int64) error {
+		result, err := checkUser(ctx, userID, input.Repair)
+		if err != nil {
+			return err
+		}
+		output.Checked++
+		if result.OK() {
+			return nil
+		}
+		logging.Ctx(ctx).Warn("reconcile discrepancy",
+			zap.Int64("user_id", result.UserID),
+			zap.Int64("balance", result.Balance),
+			zap.Int64("account_balance", result.AccountBalance),
+			zap.Int64("ledger_balance", result.LedgerBalance),
+			zap.Int("chain_breaks", len(result.ChainBreaks)),
+			zap.Bool("repaired", result.Repaired),
+		)
+		output.Found++
+		if result.Repaired {
+			output.Repaired++
+		}
+		if len(output.Discrepancies) < maxDiscrepancyList {
+			output.Discrepancies = append(output.Discrepancies, result)
+		}
+		return nil
+	}
+
+	if len(input.UserIDs) > 0 {
+		for _, userID := range input.UserIDs {
+			if err := check(userID); err != nil {
+				return nil, err
+			}
+		}
+	} else if err := eachUser(ctx, check); err != nil {
+		return nil, err
+	}
+	metrics.ReconcileDiscrepancies.Set(float64(output.Found))
+	return output, nil
+}
+
+// eachUser 按 user_points 表的主键分批遍历所有用户
+func eachUser(ctx context.Context, fn func(userID int64) error) error {
+	up := query.UserPoint
+	var lastID int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		list, err := up.WithContext(ctx).
+			Select(up.ID, up.UserID).
+			Where(up.ID.Gt(lastID)).
+			Order(up.ID).
+			Limit(batchSize).
+			Find()
+		if err != nil {
+			logging.Ctx(ctx).Error("query user_points error", zap.Error(err))
+			return err
+		}
+		for _, v := range list {
+			if err := fn(v.UserID); err != nil {
+				return err
+			}
+		}
+		if len(list) < batchSize {
+			return nil
+		}
+		lastID = list[len(list)-1].ID
+	}
+}
+
+// checkUser 检查单个用户，发现不一致时加锁重新确认，需要修复时通过记账追加校正分录
+func checkUser(ctx context.Context, userID int64, repair bool) (*model.ReconcileResult, error) {
+	// 1. 不加锁遍历流水检查余额链，读主库避免主从延迟导致误报
+	result := &model.ReconcileResult{UserID: userID}
+	t := query.UserPointsTransaction
+	var lastID, balance int64
+	for {
+		list, err := t.WithContext(ctx).WriteDB().
+			Select(t.ID, t.PointsChange, t.CurrentBalance).
+			Where(t.UserID.Eq(userID), t.ID.Gt(lastID)).
+			Order(t.ID).
+			Limit(batchSize).
+			Find()
+		if err != nil {
+			logging.Ctx(ctx).Error("query user_points_transactions error", zap.Int64("user_id", userID), zap.Error(err))
+			return nil, err
+		}
+		for _, v := range list {
+			balance += v.PointsChange
+			if v.CurrentBalance != balance && len(result.ChainBreaks) < maxChainBreaks {
+				result.ChainBreaks = append(result.ChainBreaks, &model.ChainBreak{
+					TransactionID: v.ID,
+					Expected:      balance,
+					Actual:        v.CurrentBalance,
+				})
+			}
+		}
+		if len(list) < batchSize {
+			break
+		}
+		lastID = list[len(list)-1].ID
+	}
+	// 2. 不加锁比较 user_points、钱包余额和记账明细汇总，还没有钱包的用户与流水累加的余额比较
+	wallet, err := ledger.Wallet(ctx, query.Q, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	if wallet == nil {
+		result.AccountBalance, result.LedgerBalance = balance, balance
+		if result.Balance, _, err = userPoints(ctx, query.Q, userID, false); err != nil {
+			return nil, err
+		}
+	} else if err := fill(ctx, query.Q, result, wallet); err != nil {
+		return nil, err
+	}
+	if result.Balanced() {
+		return result, nil
+	}
+	// 3. 两次查询之间可能有新的积分变动，加锁后重新确认，确认不一致时按需修复
+	if err := confirm(ctx, result, repair); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// fill 填充对账结果中的 user_points 余额、钱包余额和记账明细汇总
+func fill(ctx context.Context, tx *query.Query, result *model.ReconcileResult, wallet *model.LedgerAccount) error {
+	sum, err := ledger.PostingsSum(ctx, tx, wallet.ID)
+	if err != nil {
+		return err
+	}
+	if result.Balance, _, err = userPoints(ctx, tx, result.UserID, false); err != nil {
+		return err
+	}
+	result.AccountBalance, result.LedgerBalance = wallet.Balance, sum
+	return nil
+}
+
+// userPoints 查询 user_points 表中的余额，没有记录时余额为 0、found 为 false，lock 为 true 时加锁读取
+func userPoints(ctx context.Context, tx *query.Query, userID int64, lock bool) (balance int64, found bool, err error) {
+	do := tx.UserPoint.WithContext(ctx).WriteDB()
+	if lock {
+		do = do.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	upInst, err := do.Where(tx.UserPoint.UserID.Eq(userID)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, false, nil
+		}
+		logging.Ctx(ctx).Error("query user_points error", zap.Int64("user_id", userID), zap.Error(err))
+		return 0, false, err
+	}
+	return upInst.Points, true, nil
+}
+
+// confirm 锁住用户钱包后重新对账，积分变动都通过 ledger.Post 记账，记账时会先锁住钱包，
+// 所以拿到锁之后钱包、记账明细和 user_points 都不会再有进行中的变动
+// 修复时钱包余额以记账明细为准，user_points 与记账明细的差额记一笔校正分录（系统发放账户与钱包之间），
+// 记账后钱包、记账明细和 user_points 三者一致，历史流水和分录都不改动
+func confirm(ctx context.Context, result *model.ReconcileResult, repair bool) error {
+	return query.Q.Transaction(func(tx *query.Query) error {
+		wallet, err := ledger.Wallet(ctx, tx, result.UserID, true)
+		if err != nil {
+			return err
+		}
+		if wallet == nil {
+			return confirmNoWallet(ctx, tx, result, repair)
+		}
+		if err := fill(ctx, tx, result, wallet); err != nil {
+			return err
+		}
+		if result.Balanced() || !repair {
+			return nil
+		}
+		// 1. 钱包余额与记账明细不一致时以记账明细为准
+		if wallet.Balance != result.LedgerBalance {
+			if err := ledger.ResetBalance(ctx, tx, wallet, result.LedgerBalance); err != nil {
+				return err
+			}
+		}
+		// 2. user_points 与记账明细的差额记一笔校正分录，记账时同步写入校正流水
+		if diff := result.Balance - result.LedgerBalance; diff != 0 {
+			if _, err := ledger.Post(ctx, tx, &model.LedgerEntryInput{
+				Type:    model.PointsTransactionTypeCorrection,
+				DescKey: correctionDescKey,
+				Lines: []*model.LedgerLine{
+					{AccountType: model.LedgerAccountTypeIssuance, Amount: -diff},
+					{AccountType: model.LedgerAccountTypeUserWallet, OwnerID: result.UserID, Amount: diff},
+				},
+			}); err != nil {
+				return err
+			}
+		}
+		result.Repaired = true
+		return nil
+	})
+}
+
+// confirmNoWallet 锁住 user_points 记录后重新比较还没有钱包的用户，补签等不记账的积分流水写入前也会先锁住 user_points
+// 修复时追加一条校正流水，校正后流水累加的余额等于 user_points 的余额，user_points 没有记录时只报告不修复
+func confirmNoWallet(ctx context.Context, tx *query.Query, result *model.ReconcileResult, repair bool) error {
+	balance, found, err := userPoints(ctx, tx, result.UserID, true)
+	if err != nil {
+		return err
+	}
+	var sum int64
+	if err := tx.UserPointsTransaction.WithContext(ctx).WriteDB().
+		Where(tx.UserPointsTransaction.UserID.Eq(result.UserID)).
+		UnderlyingDB().
+		Select("COALESCE(SUM(points_change), 0)").
+		Scan(&sum).Error; err != nil {
+		logging.Ctx(ctx).Error("sum user_points_transactions error", zap.Int64("user_id", result.UserID), zap.Error(err))
+		return err
+	}
+	result.Balance, result.AccountBalance, result.LedgerBalance = balance, sum, sum
+	if result.Balanced() || !repair || !found {
+		return nil
+	}
+	extJSON, err := model.TransactionExt{DescKey: correctionDescKey}.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := tx.UserPointsTransaction.WithContext(ctx).Create(&model.UserPointsTransaction{
+		UserID:          result.UserID,
+		PointsChange:    balance - sum,
+		CurrentBalance:  balance,
+		TransactionType: int32(model.PointsTransactionTypeCorrection),
+		Description:     i18n.T(i18n.DefaultLang, correctionDescKey), // 入库的是默认语言，展示时按 ExtJSON 重新翻译
+		ExtJSON:         extJSON,
+	}); err != nil {
+		logging.Ctx(ctx).Error("create correction user_points_transactions error", zap.Int64("user_id", result.UserID), zap.Error(err))
+		return err
+	}
+	result.Repaired = true
+	return nil
+}
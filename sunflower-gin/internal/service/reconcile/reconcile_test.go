@@ -0,0 +1,69 @@
+package reconcile
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/model"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestCheckUserWithoutWallet 还没有钱包的用户，user_points 与流水累加的余额不一致时报告并追加校正流水
+func TestCheckUserWithoutWallet(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "reconcile.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&model.UserPoint{}, &model.UserPointsTransaction{}, &model.LedgerAccount{}); err != nil {
+		t.Fatal(err)
+	}
+	query.SetDefault(db)
+	ctx := context.Background()
+
+	// 流水累加为 15，user_points 被改成了 30
+	const userID = 1
+	if err := db.Create(&model.UserPoint{UserID: userID, Points: 30}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create([]*model.UserPointsTransaction{
+		{UserID: userID, PointsChange: 10, CurrentBalance: 10},
+		{UserID: userID, PointsChange: 5, CurrentBalance: 15},
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := checkUser(ctx, userID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.OK() || result.Repaired || result.Balance != 30 || result.LedgerBalance != 15 {
+		t.Fatalf("check without repair = %+v, want balance 30, ledger balance 15, not repaired", result)
+	}
+
+	result, err = checkUser(ctx, userID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Repaired {
+		t.Fatalf("check with repair = %+v, want repaired", result)
+	}
+	var last model.UserPointsTransaction
+	if err := db.Where("user_id = ?", userID).Order("id DESC").First(&last).Error; err != nil {
+		t.Fatal(err)
+	}
+	if last.TransactionType != int32(model.PointsTransactionTypeCorrection) || last.PointsChange != 15 || last.CurrentBalance != 30 {
+		t.Errorf("correction = %+v, want +15 with balance 30", last)
+	}
+
+	result, err = checkUser(ctx, userID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.OK() {
+		t.Errorf("check after repair = %+v, want ok", result)
+	}
+}
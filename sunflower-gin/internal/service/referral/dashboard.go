@@ -0,0 +1,105 @@
+package referral
+
+import (
+	"context"
+
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/logging"
+
+	"go.uber.org/zap"
+)
+
+// Dashboard 邀请人的邀请码、奖励规则和邀请统计
+func Dashboard(ctx context.Context, userID int64) (*model.ReferralDashboard, error) {
+	code, err := Code(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	rule := conf.Get().Referral
+	output := &model.ReferralDashboard{
+		Code:             code,
+		RequiredCheckins: rule.RequiredCheckins,
+		InviterPoints:    rule.InviterPoints,
+		InviteePoints:    rule.InviteePoints,
+	}
+	// 按状态统计邀请人数和获得的积分
+	var rows []struct {
+		Status int32
+		Count  int64
+		Points int64
+	}
+	r := query.Referral
+	do := r.WithContext(ctx)
+	if dao.ReadPrimary(ctx, userID) {
+		do = do.WriteDB()
+	}
+	if err := do.Where(r.InviterID.Eq(userID)).
+		UnderlyingDB().
+		Select("status, COUNT(*) AS count, COALESCE(SUM(inviter_points), 0) AS points").
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		logging.Ctx(ctx).Error("stats referrals error", zap.Error(err))
+		return nil, err
+	}
+	for _, v := range rows {
+		output.Invited += v.Count
+		output.EarnedPoints += v.Points
+		switch model.ReferralStatus(v.Status) {
+		case model.ReferralStatusPending:
+			output.Pending = v.Count
+		case model.ReferralStatusRewarded:
+			output.Rewarded = v.Count
+		case model.ReferralStatusRejected:
+			output.Rejected = v.Count
+		}
+	}
+	return output, nil
+}
+
+// Invitees 分页查询邀请的用户，按注册时间倒序
+func Invitees(ctx context.Context, input *model.ReferralInviteeListInput) (*model.ReferralInviteeListOutput, error) {
+	r := query.Referral
+	do := r.WithContext(ctx)
+	if dao.ReadPrimary(ctx, input.UserID) {
+		do = do.WriteDB()
+	}
+	list, total, err := do.
+		Where(r.InviterID.Eq(input.UserID)).
+		Order(r.ID.Desc()).
+		FindByPage(input.Offset, input.Limit)
+	if err != nil {
+		logging.Ctx(ctx).Error("query referrals error", zap.Error(err))
+		return nil, err
+	}
+	ids := make([]int64, 0, len(list))
+	for _, v := range list {
+		ids = append(ids, v.InviteeID)
+	}
+	names := make(map[int64]string, len(ids))
+	if len(ids) > 0 {
+		u := query.Userinfo
+		users, err := u.WithContext(ctx).Select(u.UserID, u.Username).Where(u.UserID.In(ids...)).Find()
+		if err != nil {
+			logging.Ctx(ctx).Error("query userinfo error", zap.Error(err))
+			return nil, err
+		}
+		for _, v := range users {
+			names[v.UserID] = v.Username
+		}
+	}
+	output := &model.ReferralInviteeListOutput{Total: total, List: make([]*model.ReferralInviteeInfo, 0, len(list))}
+	for _, v := range list {
+		output.List = append(output.List, &model.ReferralInviteeInfo{
+			Username:      names[v.InviteeID],
+			Status:        model.ReferralStatus(v.Status),
+			Checkins:      v.Checkins,
+			InviterPoints: v.InviterPoints,
+			RegisteredAt:  v.CreatedAt,
+			RewardedAt:    v.RewardedAt,
+		})
+	}
+	return output, nil
+}
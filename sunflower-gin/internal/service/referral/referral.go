@@ -0,0 +1,354 @@
+package referral
+
+import (
+	"context"
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"sunflower-gin/internal/cache"
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/ledger"
+	"sunflower-gin/internal/metrics"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/progress"
+	"sunflower-gin/internal/tenant"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+	"sunflower-gin/pkg/tracing"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 邀请好友
+// 新用户注册时填写邀请码绑定邀请关系，同一IP或同一设备在时间窗口内绑定过多时标记为不发放奖励，不影响注册，
+// 绑定次数用 Redis 计数，窗口从第一次绑定开始计算，注册的事务回滚时撤销计数
+// 被邀请人完成 referral.required_checkins 次每日签到后，从系统发放账户给邀请人和被邀请人发放奖励
+
+const (
+	codeLength   = 8
+	codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // 去掉容易混淆的 0/O、1/I
+	codeRetries  = 3                                  // 邀请码冲突时重新生成的次数
+
+	maxDeviceIDLen = 64 // 设备ID由客户端上报，超过字段长度时截断
+
+	ipCountKeyFormat     = "referral:bind:ip:%s"     // 窗口内同一IP绑定的次数
+	deviceCountKeyFormat = "referral:bind:device:%s" // 窗口内同一设备绑定的次数
+
+	inviterDescKey = "points.desc.referral_inviter" // 邀请%s的奖励
+	inviteeDescKey = "points.desc.referral_invitee" // 接受%s邀请的奖励
+)
+
+//go:embed window.lua
+var windowCountLua string
+
+var windowCountScript = redis.NewScript(windowCountLua)
+
+//go:embed window_release.lua
+var windowReleaseLua string
+
+var windowReleaseScript = redis.NewScript(windowReleaseLua)
+
+var (
+	ErrInvalidCode = i18n.NewError("error.referral.invalid_code") // 邀请码不存在
+)
+
+// Code 用户的邀请码，第一次查询时生成
+func Code(ctx context.Context, userID int64) (string, error) {
+	rc := query.ReferralCode
+	for range codeRetries {
+		row, err := rc.WithContext(ctx).WriteDB().Where(rc.UserID.Eq(userID)).First()
+		if err == nil {
+			return row.Code, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logging.Ctx(ctx).Error("query referral_codes error", zap.Error(err))
+			return "", err
+		}
+		// 用户ID或邀请码冲突时都不插入，重新查询后换一个邀请码再试
+		if err := rc.WithContext(ctx).UnderlyingDB().
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.ReferralCode{UserID: userID, Code: newCode()}).Error; err != nil {
+			logging.Ctx(ctx).Error("create referral_codes error", zap.Error(err))
+			return "", err
+		}
+	}
+	row, err := rc.WithContext(ctx).WriteDB().Where(rc.UserID.Eq(userID)).First()
+	if err != nil {
+		logging.Ctx(ctx).Error("query referral_codes error", zap.Error(err))
+		return "", err
+	}
+	return row.Code, nil
+}
+
+// Resolve 根据邀请码查询邀请人，邀请功能关闭时返回 0，注册时忽略邀请码
+func Resolve(ctx context.Context, code string) (int64, error) {
+	if !conf.Get().Referral.Enabled {
+		return 0, nil
+	}
+	rc := query.ReferralCode
+	row, err := rc.WithContext(ctx).Where(rc.Code.Eq(strings.ToUpper(strings.TrimSpace(code)))).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, ErrInvalidCode
+	}
+	if err != nil {
+		logging.Ctx(ctx).Error("query referral_codes error", zap.Error(err))
+		return 0, err
+	}
+	return row.UserID, nil
+}
+
+// Bind 绑定邀请关系，在创建用户的事务中执行
+// 时间窗口内同一IP或同一设备绑定过多时仍然绑定，但是标记为不发放奖励
+// 绑定次数在事务提交前计数，返回的 release 用于事务回滚时撤销计数，Bind 返回错误时已经撤销
+func Bind(ctx context.Context, tx *query.Query, input *model.ReferralBindInput) (release func(), err error) {
+	rule := conf.Get().Referral
+	r := tx.Referral
+	row := &model.Referral{
+		InviterID:  input.InviterID,
+		InviteeID:  input.InviteeID,
+		Status:     int32(model.ReferralStatusPending),
+		RegisterIP: input.RegisterIP,
+		DeviceID:   truncate(input.DeviceID, maxDeviceIDLen),
+	}
+	var counted []string
+	release = func() {
+		for _, key := range counted {
+			releaseBind(ctx, key)
+		}
+	}
+	if rule.MaxPerIP > 0 && input.RegisterIP != "" {
+		key := tenant.Key(ctx, fmt.Sprintf(ipCountKeyFormat, input.RegisterIP))
+		count := countBind(ctx, key, rule.AbuseWindow)
+		if count > 0 {
+			counted = append(counted, key)
+		}
+		if count > int64(rule.MaxPerIP) {
+			row.Status, row.RejectReason = int32(model.ReferralStatusRejected), model.ReferralRejectIPLimit
+		}
+	}
+	if row.RejectReason == "" && rule.MaxPerDevice > 0 && row.DeviceID != "" {
+		key := tenant.Key(ctx, fmt.Sprintf(deviceCountKeyFormat, row.DeviceID))
+		count := countBind(ctx, key, rule.AbuseWindow)
+		if count > 0 {
+			counted = append(counted, key)
+		}
+		if count > int64(rule.MaxPerDevice) {
+			row.Status, row.RejectReason = int32(model.ReferralStatusRejected), model.ReferralRejectDeviceLimit
+		}
+	}
+	if err := r.WithContext(ctx).Create(row); err != nil {
+		logging.Ctx(ctx).Error("create referrals error", zap.Error(err))
+		release()
+		return nil, err
+	}
+	if row.RejectReason != "" {
+		logging.Ctx(ctx).Warn("referral rejected",
+			zap.Int64("inviter_id", input.InviterID),
+			zap.Int64("invitee_id", input.InviteeID),
+			zap.String("reason", row.RejectReason),
+		)
+	}
+	metrics.ReferralsTotal.WithLabelValues(model.ReferralStatus(row.Status).String()).Inc()
+	return release, nil
+}
+
+// countBind 同一IP或同一设备在窗口内绑定次数加一，返回加一后的次数
+// 用 Redis 原子计数，并发注册时不会都读到未超限的次数，窗口从第一次绑定开始计算
+// Redis 异常时与限流一样放行，不影响注册
+func countBind(ctx context.Context, key string, window time.Duration) int64 {
+	count, err := windowCountScript.Run(ctx, dao.RedisClient, []string{key}, window.Milliseconds()).Int64()
+	if err != nil {
+		logging.Ctx(ctx).Error("referral count script error", zap.String("key", key), zap.Error(err))
+		return 0
+	}
+	return count
+}
+
+// releaseBind 撤销一次绑定计数，失败时只记录日志，计数在窗口结束后自动过期
+func releaseBind(ctx context.Context, key string) {
+	if err := windowReleaseScript.Run(ctx, dao.RedisClient, []string{key}).Err(); err != nil {
+		logging.Ctx(ctx).Warn("referral release script error", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// OnCheckin 被邀请人每日签到成功后增加签到次数，达到要求时发放奖励
+// 每日签到的幂等由 Redis 的签到记录保证，同一天只会调用一次
+func OnCheckin(ctx context.Context, userID int64) error {
+	ctx, span := tracing.Start(ctx, "referral.OnCheckin")
+	defer span.End()
+	rule := conf.Get().Referral
+	// 1. 等待中的邀请关系签到次数加一，不是被邀请人或者已经处理过时直接返回
+	r := query.Referral
+	info, err := r.WithContext(ctx).
+		Where(r.InviteeID.Eq(userID), r.Status.Eq(int32(model.ReferralStatusPending))).
+		UpdateSimple(r.Checkins.Add(1))
+	if err != nil {
+		logging.Ctx(ctx).Error("update referrals error", zap.Error(err))
+		return err
+	}
+	if info.RowsAffected == 0 || !rule.Enabled {
+		return nil
+	}
+	row, err := r.WithContext(ctx).WriteDB().Where(r.InviteeID.Eq(userID)).First()
+	if err != nil {
+		logging.Ctx(ctx).Error("query referrals error", zap.Error(err))
+		return err
+	}
+	if row.Checkins < rule.RequiredCheckins {
+		return nil
+	}
+	// 2. 达到要求的签到次数，发放奖励
+	return reward(ctx, row.ID, &rule)
+}
+
+// reward 给邀请人和被邀请人发放奖励，邀请人获得奖励的次数达到上限时只标记为不发放
+func reward(ctx context.Context, id int64, rule *conf.ReferralConfig) error {
+	var row *model.Referral
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		r := tx.Referral
+		var err error
+		row, err = r.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(r.ID.Eq(id)).
+			First()
+		if err != nil {
+			logging.Ctx(ctx).Error("lock referrals error", zap.Error(err))
+			return err
+		}
+		if model.ReferralStatus(row.Status) != model.ReferralStatusPending {
+			row = nil
+			return nil
+		}
+		now := time.Now()
+		// 1. 邀请人获得奖励的次数达到上限，先锁住邀请人的邀请码，同一邀请人的奖励依次发放，
+		// 避免多个被邀请人同时达到要求时都统计到未超限的次数
+		if rule.MaxRewardsPerInviter > 0 {
+			rc := tx.ReferralCode
+			if _, err := rc.WithContext(ctx).
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where(rc.UserID.Eq(row.InviterID)).
+				First(); err != nil {
+				logging.Ctx(ctx).Error("lock referral_codes error", zap.Error(err))
+				return err
+			}
+			count, err := r.WithContext(ctx).
+				Where(r.InviterID.Eq(row.InviterID), r.Status.Eq(int32(model.ReferralStatusRewarded))).
+				Count()
+			if err != nil {
+				logging.Ctx(ctx).Error("count referrals error", zap.Error(err))
+				return err
+			}
+			if count >= int64(rule.MaxRewardsPerInviter) {
+				row.Status, row.RejectReason = int32(model.ReferralStatusRejected), model.ReferralRejectInviterLimit
+				_, err := r.WithContext(ctx).Where(r.ID.Eq(row.ID)).
+					UpdateSimple(r.Status.Value(row.Status), r.RejectReason.Value(row.RejectReason))
+				if err != nil {
+					logging.Ctx(ctx).Error("update referrals error", zap.Error(err))
+				}
+				return err
+			}
+		}
+		// 2. 从系统发放账户转入两个用户的钱包，奖励为 0 的一方不记账
+		if err := post(ctx, tx, row, rule); err != nil {
+			return err
+		}
+		row.Status, row.InviterPoints, row.InviteePoints, row.RewardedAt = int32(model.ReferralStatusRewarded), rule.InviterPoints, rule.InviteePoints, &now
+		if _, err := r.WithContext(ctx).Where(r.ID.Eq(row.ID)).UpdateSimple(
+			r.Status.Value(row.Status),
+			r.InviterPoints.Value(row.InviterPoints),
+			r.InviteePoints.Value(row.InviteePoints),
+			r.RewardedAt.Value(now),
+		); err != nil {
+			logging.Ctx(ctx).Error("update referrals error", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil || row == nil {
+		return err
+	}
+	status := model.ReferralStatus(row.Status)
+	metrics.ReferralsTotal.WithLabelValues(status.String()).Inc()
+	if status != model.ReferralStatusRewarded {
+		logging.Ctx(ctx).Warn("referral rejected", zap.Int64("referral_id", row.ID), zap.String("reason", row.RejectReason))
+		return nil
+	}
+	for _, userID := range []int64{row.InviterID, row.InviteeID} {
+		dao.MarkWritten(ctx, userID)
+		cache.Delete(ctx, cache.PointsSummaryKey(userID))
+	}
+	metrics.PointsIssuedTotal.WithLabelValues(model.PointsTransactionTypeReferral.String()).Add(float64(row.InviterPoints + row.InviteePoints))
+	// 被邀请人签到之后会计算成就和等级，这里只计算邀请人的，失败不影响奖励发放
+	progress.OnPointsEarned(ctx, row.InviterID)
+	return nil
+}
+
+// post 记一笔邀请奖励的分录
+func post(ctx context.Context, tx *query.Query, row *model.Referral, rule *conf.ReferralConfig) error {
+	total := rule.InviterPoints + rule.InviteePoints
+	if total == 0 {
+		return nil
+	}
+	u := tx.Userinfo
+	users, err := u.WithContext(ctx).
+		Select(u.UserID, u.Username).
+		Where(u.UserID.In(row.InviterID, row.InviteeID)).
+		Find()
+	if err != nil {
+		logging.Ctx(ctx).Error("query userinfo error", zap.Error(err))
+		return err
+	}
+	names := make(map[int64]string, len(users))
+	for _, v := range users {
+		names[v.UserID] = v.Username
+	}
+	lines := []*model.LedgerLine{{AccountType: model.LedgerAccountTypeIssuance, Amount: -total}}
+	if rule.InviterPoints > 0 {
+		lines = append(lines, &model.LedgerLine{
+			AccountType: model.LedgerAccountTypeUserWallet, OwnerID: row.InviterID, Amount: rule.InviterPoints,
+			DescKey: inviterDescKey, DescArgs: []any{names[row.InviteeID]},
+		})
+	}
+	if rule.InviteePoints > 0 {
+		lines = append(lines, &model.LedgerLine{
+			AccountType: model.LedgerAccountTypeUserWallet, OwnerID: row.InviteeID, Amount: rule.InviteePoints,
+			DescKey: inviteeDescKey, DescArgs: []any{names[row.InviterID]},
+		})
+	}
+	_, err = ledger.Post(ctx, tx, &model.LedgerEntryInput{
+		Type:     model.PointsTransactionTypeReferral,
+		DescKey:  inviterDescKey,
+		DescArgs: []any{names[row.InviteeID]},
+		Ext:      model.TransactionExt{ReferralID: row.ID},
+		Lines:    lines,
+	})
+	return err
+}
+
+// truncate 按字符截断，避免切断多字节的 UTF-8 字符，MySQL 的 varchar 长度也按字符计算
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// newCode 随机生成邀请码
+func newCode() string {
+	b := make([]byte, codeLength)
+	rand.Read(b)
+	for i := range b {
+		b[i] = codeAlphabet[int(b[i])%len(codeAlphabet)]
+	}
+	return string(b)
+}
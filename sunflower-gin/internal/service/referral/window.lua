@@ -0,0 +1,9 @@
+-- 固定窗口计数，第一次计数时设置过期时间，窗口内的计数原子递增
+-- KEYS[1]: 计数key
+-- ARGV[1]: 窗口大小(毫秒)
+
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+    redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
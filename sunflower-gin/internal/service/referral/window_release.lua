@@ -0,0 +1,7 @@
+-- 撤销一次固定窗口计数，窗口已经过期时不处理，避免留下没有过期时间的计数
+-- KEYS[1]: 计数key
+
+if redis.call('EXISTS', KEYS[1]) == 1 then
+    return redis.call('DECR', KEYS[1])
+end
+return 0
@@ -0,0 +1,303 @@
+package tier
+
+import (
+	"context"
+	"errors"
+
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/metrics"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/tenant"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+	"sunflower-gin/pkg/tracing"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 用户等级
+// 等级规则在配置文件 reward.tiers 中，累计获得的积分或最长连续签到天数满足任一条件即可达到
+// 签到、补签和收到积分后重新计算等级，修改规则提高门槛后用户在下一次计算时降级，每次变化都记录到 user_tier_history
+
+const (
+	batchSize = 500 // 批量计算时分批查询的大小
+
+	directionUpgrade   = "upgrade"
+	directionDowngrade = "downgrade"
+)
+
+// Perks 用户当前等级的权益，还没有计算过等级的用户按所有人都满足的最低等级处理
+func Perks(ctx context.Context, userID int64) (*model.TierPerks, error) {
+	rules := tenant.Reward(ctx).Tiers
+	row, err := load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	perks := &model.TierPerks{Level: target(rules, 0, 0)}
+	if row != nil {
+		perks.Level = row.Level
+	}
+	if rule := findRule(rules, perks.Level); rule != nil {
+		perks.DailyPointsBonus = rule.DailyPointsBonus
+		perks.ExtraRetroTimes = rule.ExtraRetroTimes
+	}
+	return perks, nil
+}
+
+// Get 用户当前等级和下一个等级的条件
+func Get(ctx context.Context, userID int64) (*model.TierInfo, error) {
+	rules := tenant.Reward(ctx).Tiers
+	row, err := load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	lifetime, err := lifetimePoints(ctx, query.Q, userID, dao.ReadPrimary(ctx, userID))
+	if err != nil {
+		return nil, err
+	}
+	info := &model.TierInfo{LifetimePoints: lifetime}
+	if row != nil {
+		info.Level, info.BestStreak = row.Level, row.BestStreak
+	} else {
+		info.Level = target(rules, lifetime, 0)
+	}
+	lang := i18n.FromContext(ctx)
+	info.Name = name(lang, rules, info.Level)
+	// 下一个等级是比当前等级高的最低等级
+	var next *conf.TierRule
+	for i := range rules {
+		if rules[i].Level > info.Level && (next == nil || rules[i].Level < next.Level) {
+			next = &rules[i]
+		}
+	}
+	if next != nil {
+		info.Next = &model.TierRequirement{
+			Level:             next.Level,
+			Name:              i18n.T(lang, "tier."+next.Name),
+			MinLifetimePoints: next.MinLifetimePoints,
+			MinStreakDays:     next.MinStreakDays,
+		}
+	}
+	return info, nil
+}
+
+// Evaluate 按当前规则重新计算用户的等级，streakDays 为截止现在的连续签到天数，不是签到事件时传 0
+// 等级变化时返回变化前后的等级，没有变化时返回 nil
+func Evaluate(ctx context.Context, userID int64, streakDays int) (*model.TierChange, error) {
+	ctx, span := tracing.Start(ctx, "tier.Evaluate")
+	defer span.End()
+	rules := tenant.Reward(ctx).Tiers // 本次计算使用同一份规则
+	var change *model.TierChange
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		// 1. 第一次计算时创建等级记录，加锁读取当前等级
+		ut := tx.UserTier
+		if err := ut.WithContext(ctx).UnderlyingDB().
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.UserTier{UserID: userID}).Error; err != nil {
+			logging.Ctx(ctx).Error("create user_tiers error", zap.Error(err))
+			return err
+		}
+		row, err := ut.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(ut.UserID.Eq(userID)).
+			First()
+		if err != nil {
+			logging.Ctx(ctx).Error("query user_tiers error", zap.Error(err))
+			return err
+		}
+		// 2. 按累计积分和最长连续签到天数计算等级
+		lifetime, err := lifetimePoints(ctx, tx, userID, true)
+		if err != nil {
+			return err
+		}
+		best := max(row.BestStreak, int32(streakDays))
+		level := target(rules, lifetime, best)
+		if level == row.Level && best == row.BestStreak {
+			return nil
+		}
+		// 3. 保存等级，等级变化时记录历史
+		if _, err := ut.WithContext(ctx).
+			Where(ut.ID.Eq(row.ID)).
+			UpdateSimple(ut.Level.Value(level), ut.BestStreak.Value(best)); err != nil {
+			logging.Ctx(ctx).Error("update user_tiers error", zap.Error(err))
+			return err
+		}
+		if level == row.Level {
+			return nil
+		}
+		if err := tx.UserTierHistory.WithContext(ctx).Create(&model.UserTierHistory{
+			UserID:         userID,
+			FromLevel:      row.Level,
+			ToLevel:        level,
+			LifetimePoints: lifetime,
+			BestStreak:     best,
+		}); err != nil {
+			logging.Ctx(ctx).Error("create user_tier_history error", zap.Error(err))
+			return err
+		}
+		change = &model.TierChange{UserID: userID, FromLevel: row.Level, ToLevel: level}
+		return nil
+	})
+	if err != nil || change == nil {
+		return nil, err
+	}
+	dao.MarkWritten(ctx, userID)
+	direction := directionUpgrade
+	if change.ToLevel < change.FromLevel {
+		direction = directionDowngrade
+	}
+	metrics.TierChangesTotal.WithLabelValues(direction).Inc()
+	logging.Ctx(ctx).Info("tier changed",
+		zap.Int64("user_id", userID),
+		zap.Int32("from", change.FromLevel),
+		zap.Int32("to", change.ToLevel),
+	)
+	return change, nil
+}
+
+// EvaluateAll 按 user_points 表遍历所有用户重新计算等级，修改等级规则后使用
+func EvaluateAll(ctx context.Context) (*model.TierEvaluateOutput, error) {
+	ctx, span := tracing.Start(ctx, "tier.EvaluateAll")
+	defer span.End()
+	output := &model.TierEvaluateOutput{}
+	up := query.UserPoint
+	var lastID int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		list, err := up.WithContext(ctx).
+			Select(up.ID, up.UserID).
+			Where(up.ID.Gt(lastID)).
+			Order(up.ID).
+			Limit(batchSize).
+			Find()
+		if err != nil {
+			logging.Ctx(ctx).Error("query user_points error", zap.Error(err))
+			return nil, err
+		}
+		for _, v := range list {
+			change, err := Evaluate(ctx, v.UserID, 0)
+			if err != nil {
+				return nil, err
+			}
+			output.Checked++
+			switch {
+			case change == nil:
+			case change.ToLevel > change.FromLevel:
+				output.Upgraded++
+			default:
+				output.Downgraded++
+			}
+		}
+		if len(list) < batchSize {
+			return output, nil
+		}
+		lastID = list[len(list)-1].ID
+	}
+}
+
+// History 分页查询用户的等级变化记录，按时间倒序
+func History(ctx context.Context, input *model.TierHistoryListInput) (*model.TierHistoryListOutput, error) {
+	h := query.UserTierHistory
+	do := h.WithContext(ctx)
+	if dao.ReadPrimary(ctx, input.UserID) {
+		do = do.WriteDB()
+	}
+	list, total, err := do.
+		Where(h.UserID.Eq(input.UserID)).
+		Order(h.ID.Desc()).
+		FindByPage(input.Offset, input.Limit)
+	if err != nil {
+		logging.Ctx(ctx).Error("query user_tier_history error", zap.Error(err))
+		return nil, err
+	}
+	rules := tenant.Reward(ctx).Tiers
+	lang := i18n.FromContext(ctx)
+	output := &model.TierHistoryListOutput{Total: total, List: make([]*model.TierHistoryInfo, 0, len(list))}
+	for _, v := range list {
+		output.List = append(output.List, &model.TierHistoryInfo{
+			FromLevel:      v.FromLevel,
+			FromName:       name(lang, rules, v.FromLevel),
+			ToLevel:        v.ToLevel,
+			ToName:         name(lang, rules, v.ToLevel),
+			LifetimePoints: v.LifetimePoints,
+			BestStreak:     v.BestStreak,
+			CreatedAt:      v.CreatedAt,
+		})
+	}
+	return output, nil
+}
+
+// load 查询用户的等级记录，还没有计算过等级时返回 nil
+func load(ctx context.Context, userID int64) (*model.UserTier, error) {
+	ut := query.UserTier
+	do := ut.WithContext(ctx)
+	if dao.ReadPrimary(ctx, userID) {
+		do = do.WriteDB()
+	}
+	row, err := do.Where(ut.UserID.Eq(userID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logging.Ctx(ctx).Error("query user_tiers error", zap.Error(err))
+		return nil, err
+	}
+	return row, nil
+}
+
+// lifetimePoints 用户累计获得的积分，还没有积分记录时为 0
+func lifetimePoints(ctx context.Context, q *query.Query, userID int64, primary bool) (int64, error) {
+	do := q.UserPoint.WithContext(ctx)
+	if primary {
+		do = do.WriteDB()
+	}
+	up, err := do.Select(q.UserPoint.PointsTotal).Where(q.UserPoint.UserID.Eq(userID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		logging.Ctx(ctx).Error("query user_points error", zap.Error(err))
+		return 0, err
+	}
+	return up.PointsTotal, nil
+}
+
+// target 满足条件的最高等级，都不满足时为 0
+func target(rules []conf.TierRule, lifetime int64, best int32) int32 {
+	var level int32
+	for _, r := range rules {
+		if r.Level <= level {
+			continue
+		}
+		free := r.MinLifetimePoints == 0 && r.MinStreakDays == 0
+		byPoints := r.MinLifetimePoints > 0 && lifetime >= r.MinLifetimePoints
+		byStreak := r.MinStreakDays > 0 && int(best) >= r.MinStreakDays
+		if free || byPoints || byStreak {
+			level = r.Level
+		}
+	}
+	return level
+}
+
+func findRule(rules []conf.TierRule, level int32) *conf.TierRule {
+	for i := range rules {
+		if rules[i].Level == level {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// name 等级的展示名称，没有等级或者等级已经从规则中删除时为空
+func name(lang string, rules []conf.TierRule, level int32) string {
+	if rule := findRule(rules, level); rule != nil {
+		return i18n.T(lang, "tier."+rule.Name)
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package transfer
+
+import (
+	"context"
+	"time"
+
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/points"
+	"sunflower-gin/pkg/logging"
+
+	"go.uber.org/zap"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+// List 查询转赠记录，按 id 倒序做游标分页
+// 转出记录包含待确认和已过期的转赠，转入记录只包含已完成的转赠
+func List(ctx context.Context, input *model.TransferListInput) (*model.TransferListOutput, error) {
+	t := query.PointsTransfer
+	// 1. 按方向组装过滤条件
+	completed := t.Status.Eq(int32(model.PointsTransferStatusCompleted))
+	var conds []gen.Condition
+	switch input.Direction {
+	case DirectionIn:
+		conds = append(conds, t.ToUserID.Eq(input.UserID), completed)
+	case DirectionOut:
+		conds = append(conds, t.FromUserID.Eq(input.UserID))
+	default:
+		conds = append(conds, field.Or(t.FromUserID.Eq(input.UserID), field.And(t.ToUserID.Eq(input.UserID), completed)))
+	}
+	if input.Cursor != "" {
+		cursor, err := points.DecodeCursor(input.Cursor)
+		if err != nil {
+			logging.Ctx(ctx).Warn("decode transfers cursor error", zap.String("cursor", input.Cursor), zap.Error(err))
+			return nil, points.ErrInvalidCursor
+		}
+		// 转赠记录的 id 随创建时间递增，只按 id 翻页，可以用上 (from_user_id, id) 索引
+		conds = append(conds, t.ID.Lt(cursor.ID))
+	}
+	// 2. 查询一页数据，多查一条用来判断是否还有下一页，用户刚转赠过时走主库
+	do := t.WithContext(ctx)
+	if dao.ReadPrimary(ctx, input.UserID) {
+		do = do.WriteDB()
+	}
+	list, err := do.Where(conds...).Order(t.ID.Desc()).Limit(input.Limit + 1).Find()
+	if err != nil {
+		logging.Ctx(ctx).Error("query points_transfers error", zap.Error(err))
+		return nil, err
+	}
+	output := &model.TransferListOutput{}
+	if len(list) > input.Limit {
+		list = list[:input.Limit]
+		output.HasMore = true
+		output.NextCursor = points.EncodeCursor(list[len(list)-1].CreatedAt, list[len(list)-1].ID)
+	}
+	// 3. 查询对方的用户名
+	userIDs := make([]int64, 0, len(list))
+	for _, v := range list {
+		if v.FromUserID == input.UserID {
+			userIDs = append(userIDs, v.ToUserID)
+		} else {
+			userIDs = append(userIDs, v.FromUserID)
+		}
+	}
+	names, err := usernames(ctx, userIDs...)
+	if err != nil {
+		return nil, err
+	}
+	output.List = make([]*model.TransferInfo, 0, len(list))
+	for _, v := range list {
+		output.List = append(output.List, toInfo(v, input.UserID, names))
+	}
+	return output, nil
+}
+
+// toInfo 转换为 userID 视角的转赠信息
+func toInfo(transfer *model.PointsTransfer, userID int64, names map[int64]string) *model.TransferInfo {
+	info := &model.TransferInfo{
+		TransferNo:   transfer.TransferNo,
+		Direction:    DirectionOut,
+		Counterparty: names[transfer.ToUserID],
+		Amount:       transfer.Amount,
+		Status:       transfer.Status,
+		Remark:       transfer.Remark,
+		CreatedAt:    transfer.CreatedAt,
+		CompletedAt:  transfer.CompletedAt,
+		ExpireAt:     transfer.ExpireAt,
+	}
+	if transfer.FromUserID != userID {
+		info.Direction = DirectionIn
+		info.Counterparty = names[transfer.FromUserID]
+	}
+	// 过期的转赠在确认时才会更新状态，展示时直接按已过期处理
+	if transfer.Status == int32(model.PointsTransferStatusPending) &&
+		transfer.ExpireAt != nil && time.Now().After(*transfer.ExpireAt) {
+		info.Status = int32(model.PointsTransferStatusExpired)
+	}
+	return info
+}
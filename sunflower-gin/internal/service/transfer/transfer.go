@@ -0,0 +1,339 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"sunflower-gin/internal/cache"
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/dao/query"
+	"sunflower-gin/internal/ledger"
+	"sunflower-gin/internal/metrics"
+	"sunflower-gin/internal/model"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
+	"sunflower-gin/pkg/snowflake"
+	"sunflower-gin/pkg/tracing"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 用户之间转赠积分
+// 转出方钱包到转入方钱包记一笔分录，同时生成两条积分流水，流水的 ExtJSON 记录转赠单号互相关联
+// 单笔达到确认阈值时先生成待确认的转赠，用户输入登录密码确认之后再执行
+
+// 转赠方向
+const (
+	DirectionIn  = "in"  // 转入
+	DirectionOut = "out" // 转出
+)
+
+const (
+	transferOutDescKey = "points.desc.transfer_out" // 转赠给%s
+	transferInDescKey  = "points.desc.transfer_in"  // 收到%s的转赠
+)
+
+var (
+	ErrDisabled          = i18n.NewError("error.transfer.disabled")            // 积分转赠功能暂未开放
+	ErrToSelf            = i18n.NewError("error.transfer.to_self")             // 不能转赠给自己
+	ErrRecipientNotExist = i18n.NewError("error.transfer.recipient_not_exist") // 接收人不存在
+	ErrAmountTooSmall    = i18n.NewError("error.transfer.amount_too_small")    // 转赠积分低于单笔最少数量
+	ErrNoEnoughPoints    = i18n.NewError("error.transfer.no_enough_points")    // 积分不足，无法转赠
+	ErrDailyAmountLimit  = i18n.NewError("error.transfer.daily_amount_limit")  // 已超过今日转赠积分上限
+	ErrDailyCountLimit   = i18n.NewError("error.transfer.daily_count_limit")   // 已超过今日转赠次数上限
+	ErrNotFound          = i18n.NewError("error.transfer.not_found")           // 转赠记录不存在
+	ErrNotPending        = i18n.NewError("error.transfer.not_pending")         // 转赠已处理，不能重复确认
+	ErrExpired           = i18n.NewError("error.transfer.expired")             // 转赠确认已过期
+	ErrInvalidPassword   = i18n.NewError("error.transfer.invalid_password")    // 密码错误
+)
+
+// Create 发起转赠，达到确认阈值时只生成待确认的转赠，否则直接完成
+func Create(ctx context.Context, input *model.CreateTransferInput) (*model.TransferInfo, error) {
+	ctx, span := tracing.Start(ctx, "transfer.Create")
+	defer span.End()
+	rule := conf.Get().Transfer // 本次转赠使用同一份规则，避免中途热更新导致前后不一致
+	if !rule.Enabled {
+		return nil, ErrDisabled
+	}
+	if input.Amount < rule.MinAmount {
+		return nil, ErrAmountTooSmall
+	}
+	// 1. 查询接收人
+	recipient, err := query.Userinfo.WithContext(ctx).
+		Where(query.Userinfo.Username.Eq(input.ToUsername)).
+		First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRecipientNotExist
+		}
+		logging.Ctx(ctx).Error("Create: query recipient failed", zap.Error(err))
+		return nil, err
+	}
+	if recipient.UserID == input.FromUserID {
+		return nil, ErrToSelf
+	}
+	names, err := usernames(ctx, input.FromUserID, recipient.UserID)
+	if err != nil {
+		return nil, err
+	}
+	transferNo, err := snowflake.NextID()
+	if err != nil {
+		logging.Ctx(ctx).Error("Create: generate snowflake id failed", zap.Error(err))
+		return nil, err
+	}
+	transfer := &model.PointsTransfer{
+		TransferNo: transferNo,
+		FromUserID: input.FromUserID,
+		ToUserID:   recipient.UserID,
+		Amount:     input.Amount,
+		Status:     int32(model.PointsTransferStatusPending),
+		Remark:     input.Remark,
+	}
+	// 2. 大额转赠先不加锁校验余额和限额，生成待确认的转赠，确认时在事务中再校验一次
+	if rule.ConfirmThreshold > 0 && input.Amount >= rule.ConfirmThreshold {
+		if err := precheck(ctx, transfer, &rule); err != nil {
+			return nil, err
+		}
+		expireAt := time.Now().Add(rule.ConfirmTTL)
+		transfer.ExpireAt = &expireAt
+		if err := query.PointsTransfer.WithContext(ctx).Create(transfer); err != nil {
+			logging.Ctx(ctx).Error("Create: create points_transfers failed", zap.Error(err))
+			return nil, err
+		}
+		dao.MarkWritten(ctx, input.FromUserID)
+		metrics.TransferTotal.WithLabelValues(model.PointsTransferStatusPending.String()).Inc()
+		return toInfo(transfer, input.FromUserID, names), nil
+	}
+	// 3. 小额转赠直接执行
+	if err := execute(ctx, transfer, &rule, names); err != nil {
+		return nil, err
+	}
+	return toInfo(transfer, input.FromUserID, names), nil
+}
+
+// Confirm 输入登录密码确认大额转赠，只能确认自己发起的转赠
+func Confirm(ctx context.Context, input *model.ConfirmTransferInput) (*model.TransferInfo, error) {
+	ctx, span := tracing.Start(ctx, "transfer.Confirm")
+	defer span.End()
+	rule := conf.Get().Transfer
+	if !rule.Enabled {
+		return nil, ErrDisabled
+	}
+	// 1. 校验登录密码
+	userInst, err := query.Userinfo.WithContext(ctx).
+		Where(query.Userinfo.UserID.Eq(input.UserID)).
+		First()
+	if err != nil {
+		logging.Ctx(ctx).Error("Confirm: query userinfo failed", zap.Error(err))
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(userInst.Password), []byte(input.Password)); err != nil {
+		return nil, ErrInvalidPassword
+	}
+	// 2. 查询待确认的转赠，刚发起就确认时从库可能还没有同步，走主库
+	transfer, err := query.PointsTransfer.WithContext(ctx).WriteDB().
+		Where(query.PointsTransfer.TransferNo.Eq(input.TransferNo),
+			query.PointsTransfer.FromUserID.Eq(input.UserID)).
+		First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logging.Ctx(ctx).Error("Confirm: query points_transfers failed", zap.Error(err))
+		return nil, err
+	}
+	if transfer.Status != int32(model.PointsTransferStatusPending) {
+		return nil, ErrNotPending
+	}
+	if transfer.ExpireAt != nil && time.Now().After(*transfer.ExpireAt) {
+		expire(ctx, transfer)
+		return nil, ErrExpired
+	}
+	// 3. 执行转赠
+	names, err := usernames(ctx, transfer.FromUserID, transfer.ToUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := execute(ctx, transfer, &rule, names); err != nil {
+		return nil, err
+	}
+	return toInfo(transfer, input.UserID, names), nil
+}
+
+// precheck 不加锁校验转出方的余额和每日限额，用于待确认的转赠提前给出提示
+func precheck(ctx context.Context, transfer *model.PointsTransfer, rule *conf.TransferConfig) error {
+	upInst, err := query.UserPoint.WithContext(ctx).WriteDB().
+		Where(query.UserPoint.UserID.Eq(transfer.FromUserID)).
+		First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNoEnoughPoints
+		}
+		logging.Ctx(ctx).Error("precheck: query user_points failed", zap.Error(err))
+		return err
+	}
+	if upInst.Points < transfer.Amount {
+		return ErrNoEnoughPoints
+	}
+	return checkDailyLimit(ctx, query.Q, transfer.FromUserID, transfer.Amount, rule, false)
+}
+
+// checkDailyLimit 统计转出方今天已经完成的转赠，校验次数和积分是否超过每日限额
+// lock 为 true 时使用加锁读，在事务中读取最新提交的转赠，而不是事务的快照
+func checkDailyLimit(ctx context.Context, q *query.Query, userID, amount int64, rule *conf.TransferConfig, lock bool) error {
+	if rule.DailyLimitAmount <= 0 && rule.DailyLimitCount <= 0 {
+		return nil
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	var stat struct {
+		Count  int64
+		Amount int64
+	}
+	t := q.PointsTransfer
+	do := t.WithContext(ctx).WriteDB()
+	if lock {
+		do = do.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	if err := do.
+		Where(t.FromUserID.Eq(userID),
+			t.Status.Eq(int32(model.PointsTransferStatusCompleted)),
+			t.CompletedAt.Gte(today)).
+		UnderlyingDB().
+		Select("COUNT(*) AS count, COALESCE(SUM(amount), 0) AS amount").
+		Scan(&stat).Error; err != nil {
+		logging.Ctx(ctx).Error("checkDailyLimit: sum points_transfers failed", zap.Error(err))
+		return err
+	}
+	if rule.DailyLimitCount > 0 && stat.Count >= int64(rule.DailyLimitCount) {
+		return ErrDailyCountLimit
+	}
+	if rule.DailyLimitAmount > 0 && stat.Amount+amount > rule.DailyLimitAmount {
+		return ErrDailyAmountLimit
+	}
+	return nil
+}
+
+// execute 在一个事务中记一笔转出方钱包到转入方钱包的分录，生成两条积分流水并把转赠标记为已完成
+func execute(ctx context.Context, transfer *model.PointsTransfer, rule *conf.TransferConfig, names map[int64]string) error {
+	ctx, span := tracing.Start(ctx, "transfer.execute")
+	defer span.End()
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		// 1. 从转出方钱包转入转入方钱包，记账时会锁住双方的钱包账户并校验余额，同一个用户的转赠在这里串行执行
+		// 记账前的普通读取已经固定了事务的快照，后面的限额和转赠状态只能用加锁读或条件更新读取最新提交的数据
+		output, err := ledger.Post(ctx, tx, &model.LedgerEntryInput{
+			Type:     model.PointsTransactionTypeTransferOut,
+			DescKey:  transferOutDescKey,
+			DescArgs: []any{names[transfer.ToUserID]},
+			Ext:      model.TransactionExt{TransferNo: transfer.TransferNo},
+			Lines: []*model.LedgerLine{
+				{
+					AccountType: model.LedgerAccountTypeUserWallet,
+					OwnerID:     transfer.FromUserID,
+					Amount:      -transfer.Amount,
+				},
+				{
+					AccountType:     model.LedgerAccountTypeUserWallet,
+					OwnerID:         transfer.ToUserID,
+					Amount:          transfer.Amount,
+					TransactionType: model.PointsTransactionTypeTransferIn,
+					DescKey:         transferInDescKey,
+					DescArgs:        []any{names[transfer.FromUserID]},
+				},
+			},
+		})
+		if err != nil {
+			if errors.Is(err, ledger.ErrInsufficientBalance) {
+				return ErrNoEnoughPoints
+			}
+			return err
+		}
+		// 2. 加锁统计今天已完成的转赠，前面并发完成的转赠也计入限额
+		if err := checkDailyLimit(ctx, tx, transfer.FromUserID, transfer.Amount, rule, true); err != nil {
+			return err
+		}
+		// 3. 转赠标记为已完成，记录两条流水的ID
+		now := time.Now()
+		transfer.Status = int32(model.PointsTransferStatusCompleted)
+		transfer.OutTransactionID = output.Transactions[0].ID
+		transfer.InTransactionID = output.Transactions[1].ID
+		transfer.CompletedAt = &now
+		if transfer.ID == 0 {
+			if err := tx.PointsTransfer.WithContext(ctx).Create(transfer); err != nil {
+				logging.Ctx(ctx).Error("tx create points_transfers error", zap.Error(err))
+				return err
+			}
+			return nil
+		}
+		// 待确认的转赠只更新仍然是待确认状态的记录，并发确认时只有一个能成功
+		t := tx.PointsTransfer
+		info, err := t.WithContext(ctx).
+			Where(t.ID.Eq(transfer.ID), t.Status.Eq(int32(model.PointsTransferStatusPending))).
+			UpdateSimple(
+				t.Status.Value(transfer.Status),
+				t.OutTransactionID.Value(transfer.OutTransactionID),
+				t.InTransactionID.Value(transfer.InTransactionID),
+				t.CompletedAt.Value(now),
+			)
+		if err != nil {
+			logging.Ctx(ctx).Error("tx update points_transfers error", zap.Error(err))
+			return err
+		}
+		if info.RowsAffected == 0 {
+			return ErrNotPending
+		}
+		return nil
+	})
+	if err != nil {
+		logging.Ctx(ctx).Error("transfer tx failed", zap.Int64("transfer_no", transfer.TransferNo), zap.Error(err))
+		return err
+	}
+	for _, userID := range []int64{transfer.FromUserID, transfer.ToUserID} {
+		dao.MarkWritten(ctx, userID)
+		cache.Delete(ctx, cache.PointsSummaryKey(userID))
+	}
+	metrics.TransferTotal.WithLabelValues(model.PointsTransferStatusCompleted.String()).Inc()
+	metrics.TransferPointsTotal.Add(float64(transfer.Amount))
+	return nil
+}
+
+// expire 把过期的待确认转赠标记为已过期，失败时不影响返回给用户的结果，只记录日志
+func expire(ctx context.Context, transfer *model.PointsTransfer) {
+	t := query.PointsTransfer
+	info, err := t.WithContext(ctx).
+		Where(t.ID.Eq(transfer.ID), t.Status.Eq(int32(model.PointsTransferStatusPending))).
+		Update(t.Status, int32(model.PointsTransferStatusExpired))
+	if err != nil {
+		logging.Ctx(ctx).Warn("expire points_transfers error", zap.Int64("transfer_no", transfer.TransferNo), zap.Error(err))
+		return
+	}
+	if info.RowsAffected > 0 {
+		metrics.TransferTotal.WithLabelValues(model.PointsTransferStatusExpired.String()).Inc()
+	}
+}
+
+// usernames 批量查询用户名
+func usernames(ctx context.Context, userIDs ...int64) (map[int64]string, error) {
+	names := make(map[int64]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return names, nil
+	}
+	u := query.Userinfo
+	list, err := u.WithContext(ctx).
+		Select(u.UserID, u.Username).
+		Where(u.UserID.In(userIDs...)).
+		Find()
+	if err != nil {
+		logging.Ctx(ctx).Error("query usernames error", zap.Error(err))
+		return nil, err
+	}
+	for _, v := range list {
+		names[v.UserID] = v.Username
+	}
+	return names, nil
+}
@@ -2,9 +2,14 @@ package user
 
 import (
 	"context"
-	"errors"
+	"sunflower-gin/internal/cache"
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/dao"
 	"sunflower-gin/internal/dao/query"
 	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/referral"
+	"sunflower-gin/pkg/i18n"
+	"sunflower-gin/pkg/logging"
 	"sunflower-gin/pkg/snowflake"
 
 	"go.uber.org/zap"
@@ -16,7 +21,8 @@ const (
 )
 
 var (
-	ErrUserExist = errors.New("用户名已存在")
+	ErrUserExist    = i18n.NewError("error.user.exist")     // 用户名已存在
+	ErrUserNotExist = i18n.NewError("error.user.not_exist") // 用户不存在
 )
 
 // 业务逻辑层
@@ -27,30 +33,38 @@ func Create(ctx context.Context, input *model.CreateUserInput) (*model.CreateUse
 	// 传统的GORM查询
 	// dao.DB.WithContext(ctx).Model(&model.Userinfo{}).Where("username = ?", input.Username)
 	// GORM GEN 生成代码的查询
-	count, err := query.Userinfo.WithContext(ctx).
+	// 用户名唯一性校验走主库，避免主从延迟时重复注册
+	count, err := query.Userinfo.WithContext(ctx).WriteDB().
 		Where(query.Userinfo.Username.Eq(input.Username)).
 		Count()
 	if err != nil {
-		zap.L().Error("Create: query userinfo failed", zap.Error(err))
+		logging.Ctx(ctx).Error("Create: query userinfo failed", zap.Error(err))
 		return nil, err
 	}
 	if count > 0 {
 		return nil, ErrUserExist
 	}
+	// 填写了邀请码时先确认邀请码有效
+	var inviterID int64
+	if input.InviteCode != "" {
+		if inviterID, err = referral.Resolve(ctx, input.InviteCode); err != nil {
+			return nil, err
+		}
+	}
 	// 2. 创建用户
 	// 传统的密码加密是加点盐算个md5 之类的，
 	// 进阶一点的做法是使用bcrypt库进行密码加密,及时相同的密码，每次生成的hash值都不一样
 	// 密码加密 bcrypt 加密后的密码长度固定为 60 字符。
 	hashedPwd, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
 	if err != nil {
-		zap.L().Error("Create: bcrypt generate password failed", zap.Error(err))
+		logging.Ctx(ctx).Error("Create: bcrypt generate password failed", zap.Error(err))
 		return nil, err
 	}
-	zap.L().Sugar().Debugf("--> hashPwd:%s\n", string(hashedPwd))
+	logging.Ctx(ctx).Sugar().Debugf("--> hashPwd:%s\n", string(hashedPwd))
 	// 使用雪花算法生成唯一的 user id
 	uid, err := snowflake.NextID()
 	if err != nil {
-		zap.L().Error("Create: generate snowflake id failed", zap.Error(err))
+		logging.Ctx(ctx).Error("Create: generate snowflake id failed", zap.Error(err))
 		return nil, err
 	}
 	user := &model.Userinfo{
@@ -60,8 +74,29 @@ func Create(ctx context.Context, input *model.CreateUserInput) (*model.CreateUse
 		Email:    input.Email,
 		Avatar:   defaultAvatar,
 	}
-	if err := query.Userinfo.WithContext(ctx).Create(user); err != nil {
-		zap.L().Error("Create: create userinfo failed", zap.Error(err))
+	// 创建用户和绑定邀请关系在同一个事务中，事务回滚时撤销邀请的绑定计数
+	var releaseBind func()
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if err := tx.Userinfo.WithContext(ctx).Create(user); err != nil {
+			logging.Ctx(ctx).Error("Create: create userinfo failed", zap.Error(err))
+			return err
+		}
+		if inviterID == 0 {
+			return nil
+		}
+		var err error
+		releaseBind, err = referral.Bind(ctx, tx, &model.ReferralBindInput{
+			InviterID:  inviterID,
+			InviteeID:  user.UserID,
+			RegisterIP: input.ClientIP,
+			DeviceID:   input.DeviceID,
+		})
+		return err
+	})
+	if err != nil {
+		if releaseBind != nil {
+			releaseBind()
+		}
 		return nil, err
 	}
 	// 3. 返回结果
@@ -71,14 +106,26 @@ func Create(ctx context.Context, input *model.CreateUserInput) (*model.CreateUse
 	}, nil
 }
 
-// GetProfile 获取用户信息
+// GetProfile 获取用户信息，优先读缓存，修改用户信息时删除缓存
 func GetProfile(ctx context.Context, userID int64) (*model.UserProfileOutput, error) {
-	// 根据userID查库
-	userInst, err := query.Userinfo.WithContext(ctx).
+	return cache.GetOrLoad(ctx, cache.NameUserProfile, cache.UserProfileKey(userID), conf.Get().Cache.UserProfileTTL,
+		func(ctx context.Context) (*model.UserProfileOutput, error) {
+			return loadProfile(ctx, userID)
+		})
+}
+
+// loadProfile 从数据库查询用户信息
+func loadProfile(ctx context.Context, userID int64) (*model.UserProfileOutput, error) {
+	// 根据userID查库，用户刚写入过数据时走主库
+	do := query.Userinfo.WithContext(ctx)
+	if dao.ReadPrimary(ctx, userID) {
+		do = do.WriteDB()
+	}
+	userInst, err := do.
 		Where(query.Userinfo.UserID.Eq(userID)).
 		First()
 	if err != nil {
-		zap.L().Error("GetProfile: query userinfo failed", zap.Error(err))
+		logging.Ctx(ctx).Error("GetProfile: query userinfo failed", zap.Error(err))
 		return nil, err
 	}
 	// 封装返回结果
@@ -89,3 +136,33 @@ func GetProfile(ctx context.Context, userID int64) (*model.UserProfileOutput, er
 		Avatar:   userInst.Avatar,
 	}, nil
 }
+
+// Exists 判断当前租户下是否有这个用户，供内部接口校验其它服务传入的用户ID
+func Exists(ctx context.Context, userID int64) (bool, error) {
+	count, err := query.Userinfo.WithContext(ctx).
+		Where(query.Userinfo.UserID.Eq(userID)).
+		Count()
+	if err != nil {
+		logging.Ctx(ctx).Error("Exists: query userinfo failed", zap.Error(err))
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// UpdateProfile 修改用户信息，只更新传了值的字段
+func UpdateProfile(ctx context.Context, input *model.UpdateProfileInput) error {
+	// 零值字段不会被更新
+	_, err := query.Userinfo.WithContext(ctx).
+		Where(query.Userinfo.UserID.Eq(input.UserID)).
+		Updates(&model.Userinfo{
+			Email:  input.Email,
+			Avatar: input.Avatar,
+		})
+	if err != nil {
+		logging.Ctx(ctx).Error("UpdateProfile: update userinfo failed", zap.Error(err))
+		return err
+	}
+	dao.MarkWritten(ctx, input.UserID)
+	cache.Delete(ctx, cache.UserProfileKey(input.UserID))
+	return nil
+}
@@ -0,0 +1,20 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"sunflower-gin/internal/service/mission"
+
+	"go.uber.org/zap"
+)
+
+// CleanupMissions 清理已经结束的每日、每周任务进度和过期的任务事件
+func CleanupMissions(ctx context.Context, retention, eventRetention time.Duration) error {
+	deleted, err := mission.Cleanup(ctx, retention, eventRetention)
+	if err != nil {
+		return err
+	}
+	zap.L().Info("cleanup missions finished", zap.Int64("deleted", deleted))
+	return nil
+}
@@ -4,17 +4,23 @@ import (
 	"context"
 	"fmt"
 	"sunflower-gin/internal/dao"
+	"sunflower-gin/internal/tenant"
 	"time"
 
 	_ "embed"
+
+	"github.com/redis/go-redis/v9"
 )
 
 const (
-	yearSignKeyFormat = "user:checkins:daily:%d:%d" // user:checkins:daily:12131321421312:2025
+	yearSignKeyFormat = "user:checkins:daily:{%d}:%d" // user:checkins:daily:{12131321421312}:2025
 )
 
 //go:embed remind.lua
-var remindScript string
+var remindLua string
+
+// 集群模式下脚本缓存在各个节点上，Run 遇到 NOSCRIPT 时会自动改用 EVAL
+var remindScript = redis.NewScript(remindLua)
 
 // CheckAndNotify 检查签到并发送通知
 func CheckAndNotify(ctx context.Context, remindThreshold int) error {
@@ -24,22 +30,20 @@ func CheckAndNotify(ctx context.Context, remindThreshold int) error {
 	// 或者可以在用户签到的时候记录一个 ZSet, userID:签到时间戳（如果用户量多需要拆分 Key）
 	userIDs := []uint64{25016147980058993}
 
-	// 2. 加载 lua script
-	sha, err := dao.RedisClient.ScriptLoad(ctx, remindScript).Result()
-	if err != nil {
-		fmt.Printf("ScriptLoad err: %v\n", err)
-		return err
-	}
-	// 3. 遍历判断每个用户
+	// 2. 遍历判断每个用户
 	now := time.Now()
 	for _, userID := range userIDs {
-		key := fmt.Sprintf(yearSignKeyFormat, userID, time.Now().Year())
+		// 服务退出时不再处理剩余用户
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		key := tenant.Key(ctx, fmt.Sprintf(yearSignKeyFormat, userID, time.Now().Year()))
 
 		// 计算当前日偏移量(当年第几天)
 		dayOfYearOffset := now.YearDay() - 1
 		fmt.Printf("key: %s, dayOfYearOffset: %d\n", key, dayOfYearOffset)
 		// 执行LUA脚本
-		result, err := dao.RedisClient.EvalSha(ctx, sha, []string{key}, dayOfYearOffset, remindThreshold).Int()
+		result, err := remindScript.Run(ctx, dao.RedisClient, []string{key}, dayOfYearOffset, remindThreshold).Int()
 		fmt.Printf("result: %d, err: %v\n", result, err)
 		if err != nil {
 			return err
@@ -0,0 +1,24 @@
+package task
+
+import (
+	"context"
+
+	"sunflower-gin/internal/model"
+	"sunflower-gin/internal/service/reconcile"
+
+	"go.uber.org/zap"
+)
+
+// Reconcile 积分对账，发现的问题记录到日志和监控指标中
+func Reconcile(ctx context.Context, repair bool) error {
+	output, err := reconcile.Run(ctx, &model.ReconcileInput{Repair: repair})
+	if err != nil {
+		return err
+	}
+	zap.L().Info("reconcile finished",
+		zap.Int("checked", output.Checked),
+		zap.Int("found", output.Found),
+		zap.Int("repaired", output.Repaired),
+	)
+	return nil
+}
@@ -2,9 +2,17 @@ package task
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
 	"time"
 
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/internal/metrics"
+	"sunflower-gin/internal/tenant"
+
 	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
 )
 
 // 定时任务
@@ -21,14 +29,77 @@ Month        | Yes        | 1-12 or JAN-DEC | * / , -
 Day of week  | Yes        | 0-6 or SUN-SAT  | * / , - ?
 */
 
-func MustInit(ctx context.Context) *cron.Cron {
+// running 定时任务调度器是否在运行，用于健康检查
+var running atomic.Bool
+
+func MustInit(ctx context.Context, cfg *conf.TaskConfig) *cron.Cron {
 	tz, err := time.LoadLocation("Local")
 	if err != nil {
 		panic(err)
 	}
 	c := cron.New(cron.WithLocation(tz))
 	// 添加定时任务
-	c.AddFunc("25 20 * * *", func() { CheckAndNotify(ctx, 2) })
+	c.AddFunc("25 20 * * *", runJob("check_and_notify", func() error {
+		return forEachTenant(ctx, func(ctx context.Context) error { return CheckAndNotify(ctx, 2) })
+	}))
+	if cfg.Reconcile.Spec != "" {
+		if _, err := c.AddFunc(cfg.Reconcile.Spec, runJob("reconcile", func() error {
+			return forEachTenant(ctx, func(ctx context.Context) error { return Reconcile(ctx, cfg.Reconcile.Repair) })
+		})); err != nil {
+			panic(fmt.Errorf("add reconcile job failed, err:%w", err))
+		}
+	}
+	if cfg.Missions.Spec != "" {
+		if _, err := c.AddFunc(cfg.Missions.Spec, runJob("cleanup_missions", func() error {
+			return forEachTenant(ctx, func(ctx context.Context) error {
+				return CleanupMissions(ctx, cfg.Missions.Retention, cfg.Missions.EventRetention)
+			})
+		})); err != nil {
+			panic(fmt.Errorf("add cleanup_missions job failed, err:%w", err))
+		}
+	}
 	c.Start()
+	running.Store(true)
 	return c
 }
+
+// Running 返回定时任务调度器是否在运行
+func Running() bool {
+	return running.Load()
+}
+
+// Stop 停止定时任务调度，并等待正在运行的任务执行完毕
+// ctx 超时或取消时不再等待，直接返回
+func Stop(ctx context.Context, c *cron.Cron) error {
+	running.Store(false)
+	select {
+	case <-c.Stop().Done():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// runJob 包装定时任务，记录执行结果和耗时
+func runJob(name string, fn func() error) func() {
+	return func() {
+		start := time.Now()
+		err := fn()
+		metrics.CronJobDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+		metrics.CronJobRunsTotal.WithLabelValues(name, metrics.Status(err)).Inc()
+		if err != nil {
+			zap.L().Error("cron job failed", zap.String("job", name), zap.Error(err))
+		}
+	}
+}
+
+// forEachTenant 依次对每个租户执行任务，某个租户失败不影响其他租户
+func forEachTenant(ctx context.Context, fn func(ctx context.Context) error) error {
+	var errs []error
+	for _, id := range tenant.IDs() {
+		if err := fn(tenant.WithID(ctx, id)); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,127 @@
+package tenant
+
+import (
+	"context"
+	"net"
+
+	"sunflower-gin/internal/conf"
+	"sunflower-gin/pkg/i18n"
+)
+
+// 多租户
+// 一个服务同时运行多个应用的签到，每个应用是一个租户，请求进来时由中间件识别租户并放入 ctx
+// 数据库的每张表都有 tenant_id，dao 的 GORM 插件按 ctx 中的租户自动过滤查询、填充新记录
+// Redis 的签到记录等 key 按租户加上前缀，默认租户不加前缀，兼容单租户时的数据
+// ctx 中没有租户时（如定时任务、命令行工具）属于默认租户，需要处理所有租户时用 IDs 逐个执行
+
+// Default 默认租户，没有配置多租户或者请求没有匹配到租户时使用
+const Default = "default"
+
+const keyPrefix = "tenant:" // 非默认租户的 Redis key 前缀，tenant:app2:user:checkins:daily:{123}:2025
+
+var ErrUnknown = i18n.NewError("error.tenant.unknown") // 租户不存在
+
+type ctxKey struct{}
+
+// WithID 返回带有租户ID的 ctx
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext 取出 ctx 中的租户ID，没有时返回默认租户
+func FromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(ctxKey{}).(string); ok && id != "" {
+		return id
+	}
+	return Default
+}
+
+// IDs 所有租户，默认租户排在第一个
+func IDs() []string {
+	ids := []string{Default}
+	for _, t := range conf.Get().Tenants {
+		if t.ID != Default {
+			ids = append(ids, t.ID)
+		}
+	}
+	return ids
+}
+
+// Resolve 识别请求所属的租户，依次按 app key、请求头中的租户ID和 Host 匹配，都没有时属于默认租户
+// app key 和租户ID不存在时返回 ErrUnknown，Host 没有匹配时不报错
+func Resolve(appKey, id, host string) (string, error) {
+	tenants := conf.Get().Tenants
+	if appKey != "" {
+		for _, t := range tenants {
+			for _, k := range t.AppKeys {
+				if k == appKey {
+					return t.ID, nil
+				}
+			}
+		}
+		return "", ErrUnknown
+	}
+	if id != "" {
+		if id == Default || get(id) != nil {
+			return id, nil
+		}
+		return "", ErrUnknown
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	for _, t := range tenants {
+		for _, h := range t.Hosts {
+			if h == host {
+				return t.ID, nil
+			}
+		}
+	}
+	return Default, nil
+}
+
+// Reward ctx 中租户的奖励规则，租户没有单独配置时使用全局规则
+func Reward(ctx context.Context) *conf.RewardConfig {
+	cfg := conf.Get()
+	if t := find(cfg, FromContext(ctx)); t != nil && t.Reward != nil {
+		return t.Reward
+	}
+	return &cfg.Reward
+}
+
+// Issuer ctx 中租户签发 JWT 的 issuer，租户没有配置时在 jwt.issuer 后面加上租户ID，
+// 保证一个租户签发的 token 不能在其它租户使用；默认租户没有配置时返回空，使用 jwt.issuer
+func Issuer(ctx context.Context) string {
+	cfg := conf.Get()
+	id := FromContext(ctx)
+	if t := find(cfg, id); t != nil && t.JWTIssuer != "" {
+		return t.JWTIssuer
+	}
+	if id == Default {
+		return ""
+	}
+	return cfg.JWT.Issuer + "/" + id
+}
+
+// Key ctx 中租户的 Redis key，默认租户不加前缀
+// 前缀不含 {}，不影响 key 中原有的 hash tag
+func Key(ctx context.Context, key string) string {
+	id := FromContext(ctx)
+	if id == Default {
+		return key
+	}
+	return keyPrefix + id + ":" + key
+}
+
+func get(id string) *conf.TenantConfig {
+	return find(conf.Get(), id)
+}
+
+func find(cfg *conf.Config, id string) *conf.TenantConfig {
+	for i := range cfg.Tenants {
+		if cfg.Tenants[i].ID == id {
+			return &cfg.Tenants[i]
+		}
+	}
+	return nil
+}
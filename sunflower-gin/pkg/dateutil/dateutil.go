@@ -0,0 +1,11 @@
+package dateutil
+
+import "time"
+
+// ISOWeekday ISO 8601 的星期，1-7 表示周一到周日
+func ISOWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(t.Weekday())
+}
@@ -0,0 +1,119 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// 多语言消息目录，locales 目录下每个语言一个 JSON 文件，内容是 key -> 文案
+
+const (
+	LangZhCN = "zh-CN"
+	LangEnUS = "en-US"
+
+	DefaultLang = LangZhCN // 默认语言
+
+	CtxKeyLang = "lang" // 语言上下文 key
+)
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+var (
+	catalogs = map[string]map[string]string{} // lang -> key -> message
+	// 支持的语言列表，第一个是默认语言
+	supported = []language.Tag{language.MustParse(LangZhCN), language.MustParse(LangEnUS)}
+	matcher   = language.NewMatcher(supported)
+)
+
+func init() {
+	for _, tag := range supported {
+		lang := tag.String()
+		data, err := localeFS.ReadFile(path.Join("locales", lang+".json"))
+		if err != nil {
+			panic(fmt.Errorf("load locale %s failed, err:%w", lang, err))
+		}
+		catalog := make(map[string]string)
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			panic(fmt.Errorf("parse locale %s failed, err:%w", lang, err))
+		}
+		catalogs[lang] = catalog
+	}
+}
+
+// T 按语言翻译 key 对应的文案，args 用于格式化占位符
+// 找不到时依次回退到默认语言和 key 本身
+func T(lang, key string, args ...any) string {
+	msg, ok := catalogs[lang][key]
+	if !ok {
+		msg, ok = catalogs[DefaultLang][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Match 从 Accept-Language 或用户指定的语言中匹配一个支持的语言
+// 支持 "en", "en-US", "zh-CN,zh;q=0.9,en;q=0.8" 等写法
+func Match(prefs ...string) string {
+	for _, pref := range prefs {
+		if strings.TrimSpace(pref) == "" {
+			continue
+		}
+		tags, _, err := language.ParseAcceptLanguage(pref)
+		if err != nil || len(tags) == 0 {
+			continue
+		}
+		_, idx, confidence := matcher.Match(tags...)
+		if confidence == language.No {
+			continue
+		}
+		return supported[idx].String()
+	}
+	return DefaultLang
+}
+
+// FromContext 从上下文中取出当前请求的语言
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return DefaultLang
+	}
+	if lang, ok := ctx.Value(CtxKeyLang).(string); ok && lang != "" {
+		return lang
+	}
+	return DefaultLang
+}
+
+// Error 可翻译的业务错误，Error() 返回默认语言的文案
+type Error struct {
+	Key string
+}
+
+// NewError 创建一个可翻译的业务错误
+func NewError(key string) *Error {
+	return &Error{Key: key}
+}
+
+func (e *Error) Error() string {
+	return T(DefaultLang, e.Key)
+}
+
+// ErrorMsg 返回 err 在指定语言下的文案，非 *Error 类型直接返回 err.Error()
+func ErrorMsg(lang string, err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return T(lang, e.Key)
+	}
+	return err.Error()
+}
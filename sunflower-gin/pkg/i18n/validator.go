@@ -0,0 +1,74 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/zh"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	zhTranslations "github.com/go-playground/validator/v10/translations/zh"
+)
+
+// gin binding 参数校验错误的翻译
+
+var uni *ut.UniversalTranslator
+
+// MustInitValidator 为 gin 默认的校验器注册中英文翻译
+func MustInitValidator() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		panic("gin validator engine is not *validator.Validate")
+	}
+	// 使用 json/form tag 作为字段名，提示信息里展示的是前端传的参数名
+	v.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name := strings.SplitN(fld.Tag.Get(tag), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return fld.Name
+	})
+
+	zhT, enT := zh.New(), en.New()
+	uni = ut.New(zhT, zhT, enT)
+	trans, _ := uni.GetTranslator(zhT.Locale())
+	if err := zhTranslations.RegisterDefaultTranslations(v, trans); err != nil {
+		panic(fmt.Errorf("register zh validator translations failed, err:%w", err))
+	}
+	trans, _ = uni.GetTranslator(enT.Locale())
+	if err := enTranslations.RegisterDefaultTranslations(v, trans); err != nil {
+		panic(fmt.Errorf("register en validator translations failed, err:%w", err))
+	}
+}
+
+// ValidationMsg 将参数校验错误翻译为指定语言，非校验错误返回 false
+func ValidationMsg(lang string, err error) (string, bool) {
+	var errs validator.ValidationErrors
+	if uni == nil || !errors.As(err, &errs) {
+		return "", false
+	}
+	trans, _ := uni.GetTranslator(translatorLocale(lang))
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		msgs = append(msgs, e.Translate(trans))
+	}
+	return strings.Join(msgs, "; "), true
+}
+
+// translatorLocale 将语言转换为 universal-translator 使用的 locale
+func translatorLocale(lang string) string {
+	if lang == LangEnUS {
+		return "en"
+	}
+	return "zh"
+}
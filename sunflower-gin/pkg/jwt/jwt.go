@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
@@ -17,38 +16,49 @@ var (
 	ErrExpiredToken     = errors.New("expired token")
 )
 
+// Config jwt 配置
+type Config struct {
+	AccessSecret         string `mapstructure:"access_secret" validate:"required"`
+	RefreshSecret        string `mapstructure:"refresh_secret" validate:"required"`
+	AccessExpireSeconds  int64  `mapstructure:"access_expire_seconds" validate:"gt=0"`
+	RefreshExpireSeconds int64  `mapstructure:"refresh_expire_seconds" validate:"gt=0"`
+	Issuer               string `mapstructure:"issuer"` // 默认的 issuer，签发和解析时没有指定 issuer 时使用
+}
+
 type JWT struct {
 	accessSecret         []byte // 访问令牌密钥
 	refreshSecret        []byte // 刷新令牌密钥
 	accessExpireSeconds  int64  // 访问令牌过期时间
 	refreshExpireSeconds int64  // 刷新令牌过期时间
+	issuer               string // 默认的签发者
 }
 
-func NewJWT(viper *viper.Viper) *JWT {
+func NewJWT(cfg *Config) *JWT {
 	return &JWT{
-		accessSecret:         []byte(viper.GetString("jwt.access_secret")),
-		refreshSecret:        []byte(viper.GetString("jwt.refresh_secret")),
-		accessExpireSeconds:  viper.GetInt64("jwt.access_expire_seconds"),
-		refreshExpireSeconds: viper.GetInt64("jwt.refresh_expire_seconds"),
+		accessSecret:         []byte(cfg.AccessSecret),
+		refreshSecret:        []byte(cfg.RefreshSecret),
+		accessExpireSeconds:  cfg.AccessExpireSeconds,
+		refreshExpireSeconds: cfg.RefreshExpireSeconds,
+		issuer:               cfg.Issuer,
 	}
 }
 
-func MustInit(cfg *viper.Viper) {
+func MustInit(cfg *Config) {
 	obj = NewJWT(cfg)
 }
 
-// GenAccessToken 生成 access token
-func GenAccessToken(userId int64, username string) (string, error) {
-	return obj.genToken(userId, username, accessToken)
+// GenAccessToken 生成 access token，issuer 为空时使用配置的默认值
+func GenAccessToken(userId int64, username, issuer string) (string, error) {
+	return obj.genToken(userId, username, issuer, accessToken)
 }
 
-// GenRefreshToken 生成 refresh token
-func GenRefreshToken(userId int64, username string) (string, error) {
-	return obj.genToken(userId, username, refreshToken)
+// GenRefreshToken 生成 refresh token，issuer 为空时使用配置的默认值
+func GenRefreshToken(userId int64, username, issuer string) (string, error) {
+	return obj.genToken(userId, username, issuer, refreshToken)
 }
 
 // genToken 生成token
-func (j *JWT) genToken(userId int64, username string, typ tokenType) (string, error) {
+func (j *JWT) genToken(userId int64, username, issuer string, typ tokenType) (string, error) {
 	var (
 		expiresAt time.Time
 		secret    []byte
@@ -68,7 +78,7 @@ func (j *JWT) genToken(userId int64, username string, typ tokenType) (string, er
 		UserId:   userId,
 		Username: username,
 		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    "liwenzhou.com",
+			Issuer:    j.issuerOr(issuer),
 			Subject:   "sunflower",
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
@@ -84,8 +94,8 @@ func (j *JWT) genToken(userId int64, username string, typ tokenType) (string, er
 	return signedToken, nil
 }
 
-// parseToken 解析 token
-func (j *JWT) parseToken(tokenString string, typ tokenType) (*CustomClaims, error) {
+// parseToken 解析 token，签发者必须是 issuer
+func (j *JWT) parseToken(tokenString, issuer string, typ tokenType) (*CustomClaims, error) {
 	var claim CustomClaims
 	token, err := jwt.ParseWithClaims(tokenString, &claim,
 		func(token *jwt.Token) (interface{}, error) {
@@ -97,7 +107,7 @@ func (j *JWT) parseToken(tokenString string, typ tokenType) (*CustomClaims, erro
 			default:
 				return nil, ErrInvalidTokenType
 			}
-		})
+		}, jwt.WithIssuer(j.issuerOr(issuer)))
 	if err != nil {
 		return nil, err
 	}
@@ -108,12 +118,20 @@ func (j *JWT) parseToken(tokenString string, typ tokenType) (*CustomClaims, erro
 	return nil, ErrInvalidToken
 }
 
-// ParseAccessToken 解析 access token
-func ParseAccessToken(tokenString string) (*CustomClaims, error) {
-	return obj.parseToken(tokenString, accessToken)
+// ParseAccessToken 解析 access token，issuer 为空时使用配置的默认值
+func ParseAccessToken(tokenString, issuer string) (*CustomClaims, error) {
+	return obj.parseToken(tokenString, issuer, accessToken)
 }
 
-// ParseRefreshToken 解析 refresh token
-func ParseRefreshToken(tokenString string) (*CustomClaims, error) {
-	return obj.parseToken(tokenString, refreshToken)
+// ParseRefreshToken 解析 refresh token，issuer 为空时使用配置的默认值
+func ParseRefreshToken(tokenString, issuer string) (*CustomClaims, error) {
+	return obj.parseToken(tokenString, issuer, refreshToken)
+}
+
+// issuerOr issuer 为空时返回默认的签发者
+func (j *JWT) issuerOr(issuer string) string {
+	if issuer == "" {
+		return j.issuer
+	}
+	return issuer
 }
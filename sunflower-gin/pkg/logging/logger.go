@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"context"
 	"net"
 	"net/http"
 	"net/http/httputil"
@@ -9,35 +10,46 @@ import (
 	"strings"
 	"time"
 
+	"sunflower-gin/pkg/tracing"
+
 	"github.com/gin-gonic/gin"
-	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-// NewLogger 创建并初始化日志记录器实例
-func NewLogger(cfg *viper.Viper) (*zap.Logger, error) {
+// Config 日志配置
+type Config struct {
+	Level      string `mapstructure:"level" validate:"oneof=debug info warn error dpanic panic fatal"`
+	Filename   string `mapstructure:"filename" validate:"required"`
+	MaxSize    int    `mapstructure:"max_size" validate:"gt=0"` // 单个日志文件的最大大小(MB)
+	MaxAge     int    `mapstructure:"max_age" validate:"gte=0"` // 日志文件保留天数
+	MaxBackups int    `mapstructure:"max_backups" validate:"gte=0"`
+}
+
+// level 文件日志的级别，支持运行时修改
+var level = zap.NewAtomicLevel()
+
+// NewLogger 创建并初始化日志记录器实例，mode 为 release 时只输出到文件
+func NewLogger(cfg *Config, mode string) (*zap.Logger, error) {
 	writeSyncer := getLogWriter(
-		cfg.GetString("log.filename"),
-		cfg.GetInt("log.max_size"),
-		cfg.GetInt("log.max_backups"),
-		cfg.GetInt("log.max_age"),
+		cfg.Filename,
+		cfg.MaxSize,
+		cfg.MaxBackups,
+		cfg.MaxAge,
 	)
 	encoder := getEncoder()
-	var l = new(zapcore.Level)
-	err := l.UnmarshalText([]byte(cfg.GetString("log.level")))
-	if err != nil {
+	if err := SetLevel(cfg.Level); err != nil {
 		return nil, err
 	}
 	var core zapcore.Core
-	if cfg.GetString("server.mode") == "release" {
-		core = zapcore.NewCore(encoder, writeSyncer, l)
+	if mode == "release" {
+		core = zapcore.NewCore(encoder, writeSyncer, level)
 	} else {
 		// 进入开发模式，日志输出到终端
 		consoleEncoder := zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
 		core = zapcore.NewTee(
-			zapcore.NewCore(encoder, writeSyncer, l),
+			zapcore.NewCore(encoder, writeSyncer, level),
 			zapcore.NewCore(consoleEncoder, zapcore.Lock(os.Stdout), zapcore.DebugLevel),
 		)
 	}
@@ -48,6 +60,40 @@ func NewLogger(cfg *viper.Viper) (*zap.Logger, error) {
 	return lg, nil
 }
 
+// SetLevel 修改日志级别，配置热更新时调用
+func SetLevel(text string) error {
+	var l zapcore.Level
+	if err := l.UnmarshalText([]byte(text)); err != nil {
+		return err
+	}
+	level.SetLevel(l)
+	return nil
+}
+
+// loggerCtxKey 上下文中保存 logger 的 key
+type loggerCtxKey struct{}
+
+// NewContext 返回保存了 logger 的新上下文
+func NewContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, l)
+}
+
+// Ctx 返回上下文中的 logger，请求的 logger 由中间件创建，带有 request_id、user_id 和 trace_id
+// 上下文中没有 logger 时（如定时任务）使用全局 logger，并附带链路追踪信息
+func Ctx(ctx context.Context) *zap.Logger {
+	if ctx == nil {
+		return zap.L()
+	}
+	if l, ok := ctx.Value(loggerCtxKey{}).(*zap.Logger); ok {
+		return l
+	}
+	traceID, spanID := tracing.IDs(ctx)
+	if traceID == "" {
+		return zap.L()
+	}
+	return zap.L().With(zap.String("trace_id", traceID), zap.String("span_id", spanID))
+}
+
 func getEncoder() zapcore.Encoder {
 	encoderConfig := zap.NewProductionEncoderConfig()
 	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
@@ -78,7 +124,7 @@ func GinLogger() gin.HandlerFunc {
 		c.Next()
 
 		cost := time.Since(start)
-		zap.L().Info(path,
+		Ctx(c).Info(path,
 			zap.Int("status", c.Writer.Status()),
 			zap.String("method", c.Request.Method),
 			zap.String("path", path),
@@ -109,7 +155,7 @@ func GinRecovery(stack bool) gin.HandlerFunc {
 
 				httpRequest, _ := httputil.DumpRequest(c.Request, false)
 				if brokenPipe {
-					zap.L().Error(c.Request.URL.Path,
+					Ctx(c).Error(c.Request.URL.Path,
 						zap.Any("error", err),
 						zap.String("request", string(httpRequest)),
 					)
@@ -120,13 +166,13 @@ func GinRecovery(stack bool) gin.HandlerFunc {
 				}
 
 				if stack {
-					zap.L().Error("[Recovery from panic]",
+					Ctx(c).Error("[Recovery from panic]",
 						zap.Any("error", err),
 						zap.String("request", string(httpRequest)),
 						zap.String("stack", string(debug.Stack())),
 					)
 				} else {
-					zap.L().Error("[Recovery from panic]",
+					Ctx(c).Error("[Recovery from panic]",
 						zap.Any("error", err),
 						zap.String("request", string(httpRequest)),
 					)
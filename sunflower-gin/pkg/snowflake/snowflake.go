@@ -5,23 +5,28 @@ import (
 	"time"
 
 	"github.com/sony/sonyflake/v2"
-	"github.com/spf13/viper"
 )
 
 var node *sonyflake.Sonyflake
 
+// Config snowflake 配置
+type Config struct {
+	StartTime string `mapstructure:"start_time" validate:"datetime=2006-01-02"` // 起始时间，格式 2025-07-01
+	MachineID int    `mapstructure:"machine_id" validate:"gte=0"`
+}
+
 // MustInit 初始化 snowflake
-func MustInit(viper *viper.Viper) {
+func MustInit(cfg *Config) {
 	// 完成 *sonyflake.Sonyflake 的初始化
 	// 1. 读取配置文件中的起始时间
-	st, err := time.Parse(time.DateOnly, viper.GetString("snowflake.start_time"))
+	st, err := time.Parse(time.DateOnly, cfg.StartTime)
 	if err != nil {
 		panic(fmt.Errorf("parse start time failed, err:%w", err))
 	}
 	settings := sonyflake.Settings{
 		StartTime: st,
 		MachineID: func() (int, error) {
-			return viper.GetInt("snowflake.machine_id"), nil
+			return cfg.MachineID, nil
 		},
 		CheckMachineID: func(int) bool { return true },
 	}
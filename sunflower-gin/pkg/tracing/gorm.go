@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// GORM 插件，为每条 SQL 创建一个 span，span 的父节点取自 db.WithContext(ctx) 传入的 ctx
+
+const (
+	gormSpanKey      = "tracing:span"
+	gormCallbackName = "tracing"
+)
+
+// GormPlugin 链路追踪 GORM 插件，只记录不带参数的 SQL，避免敏感信息泄露
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string {
+	return "tracing"
+}
+
+// Initialize 在每类操作的前后注册 callback
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register(gormCallbackName+":before_create", gormBefore("gorm.Create")),
+		cb.Create().After("gorm:create").Register(gormCallbackName+":after_create", gormAfter),
+		cb.Query().Before("gorm:query").Register(gormCallbackName+":before_query", gormBefore("gorm.Query")),
+		cb.Query().After("gorm:query").Register(gormCallbackName+":after_query", gormAfter),
+		cb.Update().Before("gorm:update").Register(gormCallbackName+":before_update", gormBefore("gorm.Update")),
+		cb.Update().After("gorm:update").Register(gormCallbackName+":after_update", gormAfter),
+		cb.Delete().Before("gorm:delete").Register(gormCallbackName+":before_delete", gormBefore("gorm.Delete")),
+		cb.Delete().After("gorm:delete").Register(gormCallbackName+":after_delete", gormAfter),
+		cb.Row().Before("gorm:row").Register(gormCallbackName+":before_row", gormBefore("gorm.Row")),
+		cb.Row().After("gorm:row").Register(gormCallbackName+":after_row", gormAfter),
+		cb.Raw().Before("gorm:raw").Register(gormCallbackName+":before_raw", gormBefore("gorm.Raw")),
+		cb.Raw().After("gorm:raw").Register(gormCallbackName+":after_raw", gormAfter),
+	)
+}
+
+func gormBefore(spanName string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Context == nil {
+			return
+		}
+		_, span := otel.Tracer("gorm").Start(db.Statement.Context, spanName,
+			trace.WithSpanKind(trace.SpanKindClient))
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func gormAfter(db *gorm.DB) {
+	v, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := v.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBSystemNameMySQL,
+		semconv.DBCollectionName(db.Statement.Table),
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	// 查不到记录属于正常业务情况，不标记为错误
+	if err := db.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
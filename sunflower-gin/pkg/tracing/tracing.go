@@ -0,0 +1,100 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 链路追踪，基于 OpenTelemetry
+
+const (
+	ExporterOTLP   = "otlp"   // 通过 OTLP/HTTP 上报到 collector
+	ExporterStdout = "stdout" // 输出到终端，本地调试使用
+)
+
+// Config 链路追踪配置
+type Config struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	Exporter    string  `mapstructure:"exporter" validate:"oneof=otlp stdout"`
+	Endpoint    string  `mapstructure:"endpoint"` // otlp collector 地址，如 127.0.0.1:4318
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio" validate:"gte=0,lte=1"`
+}
+
+// Service 上报到链路追踪系统的服务信息
+type Service struct {
+	Name    string
+	Version string
+	Env     string
+}
+
+// MustInit 初始化 OpenTelemetry，返回的函数用于服务退出时上报剩余的 span 并关闭 exporter
+// 未开启链路追踪时使用全局默认的 noop 实现，不产生任何开销
+func MustInit(cfg *Config, svc Service) func(context.Context) error {
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }
+	}
+	exporter, err := newExporter(cfg)
+	if err != nil {
+		panic(fmt.Errorf("init tracing exporter failed, err:%w", err))
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(svc.Name),
+		semconv.ServiceVersion(svc.Version),
+		semconv.DeploymentEnvironmentName(svc.Env),
+	))
+	if err != nil {
+		panic(fmt.Errorf("init tracing resource failed, err:%w", err))
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		// 上游请求带了采样标记时沿用上游的决定，否则按比例采样
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+	return tp.Shutdown
+}
+
+func newExporter(cfg *Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(context.Background(), opts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %q", cfg.Exporter)
+	}
+}
+
+// Start 在当前上下文中开启一个子 span，用于标记 service 层的关键步骤
+func Start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return otel.Tracer("sunflower-gin").Start(ctx, name)
+}
+
+// IDs 返回上下文中的 trace id 和 span id，没有有效 span 时返回空字符串
+func IDs(ctx context.Context) (traceID, spanID string) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return "", ""
+	}
+	return sc.TraceID().String(), sc.SpanID().String()
+}
@@ -0,0 +1,4 @@
+-- 积分记录游标分页：WHERE user_id = ? AND (created_at, id) < (?, ?) ORDER BY created_at DESC, id DESC
+-- 联合索引覆盖过滤和排序，翻页时不需要扫描前面的记录
+ALTER TABLE `user_points_transactions`
+    ADD INDEX `idx_user_created_id` (`user_id`, `created_at`, `id`);
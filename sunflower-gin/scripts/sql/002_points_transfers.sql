@@ -0,0 +1,24 @@
+-- 用户之间转赠积分
+-- 转出和转入各记一条 user_points_transactions 流水，流水 ext_json 中的 transferNo 关联到本表
+CREATE TABLE `points_transfers` (
+    `id`                 BIGINT       NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `transfer_no`        BIGINT       NOT NULL COMMENT '转赠单号',
+    `from_user_id`       BIGINT       NOT NULL COMMENT '转出用户ID',
+    `to_user_id`         BIGINT       NOT NULL COMMENT '转入用户ID',
+    `amount`             BIGINT       NOT NULL COMMENT '转赠积分',
+    `status`             TINYINT      NOT NULL COMMENT '状态 1:待确认 2:已完成 3:已过期',
+    `remark`             VARCHAR(64)  NOT NULL DEFAULT '' COMMENT '留言',
+    `out_transaction_id` BIGINT       NOT NULL DEFAULT 0 COMMENT '转出流水ID',
+    `in_transaction_id`  BIGINT       NOT NULL DEFAULT 0 COMMENT '转入流水ID',
+    `expire_at`          DATETIME     NULL COMMENT '待确认的转赠过期时间',
+    `completed_at`       DATETIME     NULL COMMENT '完成时间',
+    `created_at`         DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`         DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `deleted_at`         DATETIME     NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_transfer_no` (`transfer_no`),
+    -- 转出记录和每日限额统计
+    KEY `idx_from_user_id` (`from_user_id`, `id`),
+    -- 转入记录
+    KEY `idx_to_user_id` (`to_user_id`, `id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='积分转赠';
@@ -0,0 +1,36 @@
+-- 复式记账
+-- 每一笔积分变动是一条分录（ledger_entries），分录下的多行记账（ledger_postings）金额之和必须为 0
+-- user_points 和 user_points_transactions 由记账时同步维护，作为用户钱包账户的余额和流水继续对外提供查询
+-- 已有用户的钱包账户在第一次记账时自动创建，并按 user_points 的余额记一笔期初分录，不需要迁移数据
+CREATE TABLE `ledger_accounts` (
+    `id`         BIGINT   NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `type`       TINYINT  NOT NULL COMMENT '账户类型 1:用户钱包 2:系统发放 3:活动奖池 4:销毁',
+    `owner_id`   BIGINT   NOT NULL DEFAULT 0 COMMENT '用户钱包为用户ID，活动奖池为活动ID，系统账户为0',
+    `balance`    BIGINT   NOT NULL DEFAULT 0 COMMENT '余额，系统账户（发放、销毁）不维护余额，为 0，需要时按 ledger_postings 汇总',
+    `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_type_owner` (`type`, `owner_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='记账账户';
+
+CREATE TABLE `ledger_entries` (
+    `id`               BIGINT       NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `transaction_type` INT          NOT NULL COMMENT '交易类型，与 user_points_transactions 相同',
+    `description`      VARCHAR(255) NOT NULL DEFAULT '' COMMENT '描述，默认语言',
+    `ext_json`         VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '扩展信息',
+    `created_at`       DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_created_at` (`created_at`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='记账分录';
+
+CREATE TABLE `ledger_postings` (
+    `id`            BIGINT   NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `entry_id`      BIGINT   NOT NULL COMMENT '分录ID',
+    `account_id`    BIGINT   NOT NULL COMMENT '账户ID',
+    `amount`        BIGINT   NOT NULL COMMENT '金额，正数转入该账户，负数从该账户转出',
+    `balance_after` BIGINT   NOT NULL COMMENT '记账后的账户余额，系统账户为 0',
+    `created_at`    DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_entry_id` (`entry_id`),
+    KEY `idx_account_id` (`account_id`, `id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='记账明细';
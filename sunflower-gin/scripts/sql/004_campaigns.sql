@@ -0,0 +1,34 @@
+-- 营销活动
+-- 倍率活动在活动期间按倍率多发每日签到积分，目标活动在活动期间签到满指定天数后一次性发放奖励
+-- 设置了预算的活动创建时从系统发放账户转入活动奖池（ledger_accounts type=3, owner_id=活动ID），活动奖励从奖池转出，奖池余额不足时不再发放
+CREATE TABLE `campaigns` (
+    `id`           BIGINT       NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `name`         VARCHAR(64)  NOT NULL COMMENT '活动名称',
+    `type`         TINYINT      NOT NULL COMMENT '活动类型 1:签到积分倍率 2:签到天数目标',
+    `status`       TINYINT      NOT NULL DEFAULT 1 COMMENT '状态 1:启用 2:停用',
+    `start_at`     DATETIME     NOT NULL COMMENT '开始时间（包含）',
+    `end_at`       DATETIME     NOT NULL COMMENT '结束时间（不包含）',
+    `multiplier`   INT          NOT NULL DEFAULT 0 COMMENT '签到积分倍率，百分比，200 表示双倍',
+    `goal_days`    INT          NOT NULL DEFAULT 0 COMMENT '目标签到天数',
+    `bonus_points` BIGINT       NOT NULL DEFAULT 0 COMMENT '达成目标的奖励积分',
+    `budget`       BIGINT       NOT NULL DEFAULT 0 COMMENT '活动预算，0 表示不限制',
+    `rules_json`   VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '参与条件',
+    `created_at`   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `deleted_at`   DATETIME     NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_end_at` (`end_at`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='营销活动';
+
+CREATE TABLE `campaign_user_progress` (
+    `id`           BIGINT   NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `campaign_id`  BIGINT   NOT NULL COMMENT '活动ID',
+    `user_id`      BIGINT   NOT NULL COMMENT '用户ID',
+    `checkin_days` INT      NOT NULL DEFAULT 0 COMMENT '活动期间的签到天数',
+    `completed_at` DATETIME NULL COMMENT '达成目标并发放奖励的时间',
+    `created_at`   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_campaign_user` (`campaign_id`, `user_id`),
+    KEY `idx_user_id` (`user_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='用户的活动目标进度';
@@ -0,0 +1,15 @@
+-- 成就徽章
+-- 成就的定义在代码中（internal/service/achievement），这里只记录用户的进度和获得时间
+-- 没有记录的成就进度为 0，用户下一次签到或积分变动时按历史数据重新计算，不需要迁移数据
+CREATE TABLE `user_achievements` (
+    `id`          BIGINT      NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `user_id`     BIGINT      NOT NULL COMMENT '用户ID',
+    `code`        VARCHAR(32) NOT NULL COMMENT '成就编码',
+    `progress`    BIGINT      NOT NULL DEFAULT 0 COMMENT '当前进度，达到目标值时获得成就',
+    `unlocked_at` DATETIME    NULL COMMENT '获得成就的时间',
+    `notified_at` DATETIME    NULL COMMENT '用户查看获得成就通知的时间',
+    `created_at`  DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`  DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_code` (`user_id`, `code`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='用户成就';
@@ -0,0 +1,26 @@
+-- 用户等级
+-- 等级规则在配置文件 reward.tiers 中，按累计获得的积分或最长连续签到天数计算
+-- 没有记录的用户在下一次签到或积分变动时计算等级，也可以执行 go run ./cmd/admin evaluate-tiers 批量计算
+CREATE TABLE `user_tiers` (
+    `id`          BIGINT   NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `user_id`     BIGINT   NOT NULL COMMENT '用户ID',
+    `level`       INT      NOT NULL DEFAULT 0 COMMENT '当前等级，0 表示没有等级',
+    `best_streak` INT      NOT NULL DEFAULT 0 COMMENT '最长连续签到天数',
+    `created_at`  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_id` (`user_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='用户等级';
+
+-- 等级变化记录，升级和降级都会记录，只追加不修改
+CREATE TABLE `user_tier_history` (
+    `id`              BIGINT   NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `user_id`         BIGINT   NOT NULL COMMENT '用户ID',
+    `from_level`      INT      NOT NULL COMMENT '变化前的等级',
+    `to_level`        INT      NOT NULL COMMENT '变化后的等级',
+    `lifetime_points` BIGINT   NOT NULL COMMENT '变化时累计获得的积分',
+    `best_streak`     INT      NOT NULL COMMENT '变化时最长连续签到天数',
+    `created_at`      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_user_id` (`user_id`, `id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='用户等级变化记录';
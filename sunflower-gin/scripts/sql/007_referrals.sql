@@ -0,0 +1,33 @@
+-- 邀请好友
+-- 用户第一次查看邀请信息时生成邀请码，新用户注册时填写邀请码绑定邀请关系
+-- 被邀请人完成 referral.required_checkins 次每日签到后，邀请人和被邀请人都获得奖励
+CREATE TABLE `referral_codes` (
+    `id`         BIGINT      NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `user_id`    BIGINT      NOT NULL COMMENT '用户ID',
+    `code`       VARCHAR(16) NOT NULL COMMENT '邀请码',
+    `created_at` DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_id` (`user_id`),
+    UNIQUE KEY `uk_code` (`code`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='用户邀请码';
+
+CREATE TABLE `referrals` (
+    `id`             BIGINT      NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `inviter_id`     BIGINT      NOT NULL COMMENT '邀请人用户ID',
+    `invitee_id`     BIGINT      NOT NULL COMMENT '被邀请人用户ID',
+    `status`         TINYINT     NOT NULL DEFAULT 1 COMMENT '状态 1:等待被邀请人签到 2:已发放奖励 3:不发放奖励',
+    `reject_reason`  VARCHAR(32) NOT NULL DEFAULT '' COMMENT '不发放奖励的原因 ip_limit/device_limit/inviter_limit',
+    `register_ip`    VARCHAR(64) NOT NULL DEFAULT '' COMMENT '被邀请人注册时的IP',
+    `device_id`      VARCHAR(64) NOT NULL DEFAULT '' COMMENT '被邀请人注册时的设备ID',
+    `checkins`       INT         NOT NULL DEFAULT 0 COMMENT '被邀请人注册后的每日签到次数',
+    `inviter_points` BIGINT      NOT NULL DEFAULT 0 COMMENT '邀请人获得的奖励积分',
+    `invitee_points` BIGINT      NOT NULL DEFAULT 0 COMMENT '被邀请人获得的奖励积分',
+    `rewarded_at`    DATETIME    NULL COMMENT '发放奖励的时间',
+    `created_at`     DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`     DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_invitee_id` (`invitee_id`),
+    KEY `idx_inviter_id` (`inviter_id`, `id`),
+    KEY `idx_register_ip` (`register_ip`, `created_at`),
+    KEY `idx_device_id` (`device_id`, `created_at`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='邀请关系';
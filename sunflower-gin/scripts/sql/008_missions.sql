@@ -0,0 +1,30 @@
+-- 任务
+-- 任务的定义在配置文件 mission.definitions 中，这里只记录用户每个周期的进度和领取时间
+-- 每日任务的周期为日期（20250701），每周任务为 ISO 周（2025W27），一次性任务为 once，进入新的周期后进度从 0 开始
+CREATE TABLE `user_missions` (
+    `id`           BIGINT      NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `user_id`      BIGINT      NOT NULL COMMENT '用户ID',
+    `code`         VARCHAR(32) NOT NULL COMMENT '任务编码',
+    `period_key`   VARCHAR(16) NOT NULL COMMENT '任务周期',
+    `progress`     BIGINT      NOT NULL DEFAULT 0 COMMENT '当前进度，达到目标值后不再增加',
+    `completed_at` DATETIME    NULL COMMENT '完成任务的时间',
+    `claimed_at`   DATETIME    NULL COMMENT '领取奖励的时间',
+    `created_at`   DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`   DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_code_period` (`user_id`, `code`, `period_key`),
+    KEY `idx_period_key` (`period_key`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='用户任务进度';
+
+-- 其它服务上报的任务事件，event_id 由上报方生成，重复上报时只处理一次
+CREATE TABLE `mission_events` (
+    `id`         BIGINT      NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `event_id`   VARCHAR(64) NOT NULL COMMENT '上报方生成的事件ID',
+    `user_id`    BIGINT      NOT NULL COMMENT '用户ID',
+    `event`      VARCHAR(32) NOT NULL COMMENT '事件类型',
+    `count`      BIGINT      NOT NULL COMMENT '事件次数',
+    `created_at` DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_event_id` (`event_id`),
+    KEY `idx_created_at` (`created_at`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='任务事件';
@@ -0,0 +1,44 @@
+-- 幸运抽奖
+-- 奖品池在配置文件 draw.prizes 中，每次每日签到后可以抽奖一次，这里记录每次抽奖的结果和随机数种子，用于审计时复现抽奖结果
+-- 抽中的位置 roll = HMAC-SHA256(server_seed, "{user_id}:{draw_date}:{nonce}") 的前 8 字节按大端转成整数后对 total_weight 取余，
+-- 整数超出 total_weight 整数倍的部分时消息后面加上 ":1"、":2" ... 重新计算，避免取余的偏差
+-- 有库存的奖品按配置顺序排列，roll 落在哪个奖品的权重区间就抽中哪个奖品
+CREATE TABLE `lucky_draws` (
+    `id`                BIGINT      NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `user_id`           BIGINT      NOT NULL COMMENT '用户ID',
+    `draw_date`         DATE        NOT NULL COMMENT '抽奖对应的签到日期',
+    `prize_code`        VARCHAR(32) NOT NULL DEFAULT '' COMMENT '抽中的奖品编码',
+    `prize_type`        TINYINT     NOT NULL DEFAULT 0 COMMENT '奖品类型 1:积分 2:补签卡 3:优惠券 4:谢谢参与',
+    `amount`            BIGINT      NOT NULL DEFAULT 0 COMMENT '积分数量或补签卡张数',
+    `coupon_code`       VARCHAR(32) NOT NULL DEFAULT '' COMMENT '优惠券码，只有优惠券奖品有值',
+    `coupon_expires_at` DATETIME    NULL COMMENT '优惠券过期时间',
+    `server_seed`       CHAR(64)    NOT NULL COMMENT '服务端随机数种子，hex 编码',
+    `nonce`             INT         NOT NULL DEFAULT 0 COMMENT '抽中的奖品库存不足时加一重新抽取',
+    `roll`              BIGINT      NOT NULL DEFAULT 0 COMMENT '抽中的位置，[0, total_weight)',
+    `total_weight`      BIGINT      NOT NULL DEFAULT 0 COMMENT '抽奖时有库存的奖品的权重之和',
+    `created_at`        DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_date` (`user_id`, `draw_date`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='幸运抽奖记录';
+
+-- 有库存限制的奖品已经发出的数量，配置文件中的 stock 是总库存，调整 stock 后按已发出的数量计算剩余库存
+CREATE TABLE `draw_prize_stock` (
+    `id`         BIGINT      NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `code`       VARCHAR(32) NOT NULL COMMENT '奖品编码',
+    `issued`     BIGINT      NOT NULL DEFAULT 0 COMMENT '已经发出的数量',
+    `created_at` DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_code` (`code`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='抽奖奖品库存';
+
+-- 补签卡，补签时优先使用补签卡，不消耗积分
+CREATE TABLE `user_retro_cards` (
+    `id`         BIGINT   NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `user_id`    BIGINT   NOT NULL COMMENT '用户ID',
+    `balance`    BIGINT   NOT NULL DEFAULT 0 COMMENT '剩余张数',
+    `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_id` (`user_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='用户补签卡';
@@ -0,0 +1,72 @@
+-- 多租户
+-- 所有表增加租户ID，存量数据属于默认租户 default，查询时由 dao.TenantPlugin 自动加上 tenant_id 条件，写入时自动填充
+-- 用户ID、转赠单号等全局唯一的字段不需要调整；账户、邀请码、任务事件ID和奖品编码在每个租户内唯一，唯一索引加上 tenant_id
+ALTER TABLE `campaign_user_progress`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `campaigns`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `draw_prize_stock`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `ledger_accounts`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `ledger_entries`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `ledger_postings`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `lucky_draws`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `mission_events`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `points_transfers`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `referral_codes`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `referrals`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `user_achievements`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `user_checkin_records`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `user_missions`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `user_monthly_bonus_log`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `user_points`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `user_points_transactions`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `user_retro_cards`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `user_tier_history`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `user_tiers`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+ALTER TABLE `userinfo`
+    ADD COLUMN `tenant_id` VARCHAR(32) NOT NULL DEFAULT 'default' COMMENT '租户ID' AFTER `id`;
+
+-- 系统账户（发放、消耗等）的 owner_id 为 0，每个租户各有一套
+ALTER TABLE `ledger_accounts`
+    DROP INDEX `uk_type_owner`,
+    ADD UNIQUE KEY `uk_tenant_type_owner` (`tenant_id`, `type`, `owner_id`);
+
+ALTER TABLE `referral_codes`
+    DROP INDEX `uk_code`,
+    ADD UNIQUE KEY `uk_tenant_code` (`tenant_id`, `code`);
+
+-- 不同租户的上报方可能生成相同的事件ID
+ALTER TABLE `mission_events`
+    DROP INDEX `uk_event_id`,
+    ADD UNIQUE KEY `uk_tenant_event_id` (`tenant_id`, `event_id`);
+
+-- 每个租户的奖品库存单独计算
+ALTER TABLE `draw_prize_stock`
+    DROP INDEX `uk_code`,
+    ADD UNIQUE KEY `uk_tenant_code` (`tenant_id`, `code`);
+
+-- 登录时按租户和用户名查询；用户名原有唯一索引的，需要改成 (tenant_id, username)，允许不同租户使用相同的用户名
+ALTER TABLE `userinfo`
+    ADD INDEX `idx_tenant_username` (`tenant_id`, `username`);
+
+-- 进行中的活动列表按租户查询
+ALTER TABLE `campaigns`
+    ADD INDEX `idx_tenant_end_at` (`tenant_id`, `end_at`);
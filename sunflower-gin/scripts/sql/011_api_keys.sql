@@ -0,0 +1,33 @@
+-- 服务端 API key
+-- 其它后端服务（如商城）调用内部接口时用 key_id 标识调用方，用 secret 对请求做 HMAC-SHA256 签名
+-- 签名内容为 "{method}\n{path}\n{timestamp}\n{nonce}\n{hex(sha256(body))}"，path 包含查询参数，
+-- 签名结果 hex 编码后放在请求头 X-Signature 中，服务端需要用 secret 重新计算签名，所以 secret 保存原文
+CREATE TABLE `api_keys` (
+    `id`           BIGINT       NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `tenant_id`    VARCHAR(32)  NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `key_id`       VARCHAR(32)  NOT NULL COMMENT '公开的 key 标识，请求头 X-Api-Key',
+    `secret`       VARCHAR(64)  NOT NULL COMMENT '签名密钥，只在创建时展示一次',
+    `name`         VARCHAR(64)  NOT NULL COMMENT '调用方名称',
+    `scopes`       VARCHAR(255) NOT NULL DEFAULT '' COMMENT '允许调用的接口范围，多个用逗号分隔',
+    `last_used_at` DATETIME     NULL COMMENT '最后一次调用的时间',
+    `revoked_at`   DATETIME     NULL COMMENT '吊销时间，吊销后不能再调用',
+    `created_at`   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_key_id` (`key_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='服务端 API key';
+
+-- 通过内部接口给用户发放的积分，request_id 由调用方生成，同一个 key 重复提交同一个 request_id 只发放一次
+CREATE TABLE `points_credits` (
+    `id`          BIGINT       NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `tenant_id`   VARCHAR(32)  NOT NULL DEFAULT 'default' COMMENT '租户ID',
+    `api_key_id`  BIGINT       NOT NULL COMMENT '调用方的 API key ID',
+    `request_id`  VARCHAR(64)  NOT NULL COMMENT '调用方生成的请求ID',
+    `user_id`     BIGINT       NOT NULL COMMENT '用户ID',
+    `points`      BIGINT       NOT NULL COMMENT '发放的积分',
+    `reason`      VARCHAR(128) NOT NULL DEFAULT '' COMMENT '发放原因，显示在用户的积分记录中',
+    `created_at`  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_key_request` (`api_key_id`, `request_id`),
+    KEY `idx_user_id` (`user_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT ='内部接口发放的积分';